@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"log"
 	"os"
 	"time"
@@ -11,18 +12,35 @@ import (
 	"github.com/CloudNativeWorks/elchi-backend/controller/api/router"
 	"github.com/CloudNativeWorks/elchi-backend/controller/bridge"
 	"github.com/CloudNativeWorks/elchi-backend/controller/client"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/bundle"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/cascade"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/changeset"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/clone"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/custom"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/draft"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/extension"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/lints"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/relabel"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/rename"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/revision"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/scenario"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/schemas"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/search"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/trashbin"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/upgrade"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/xds"
 	"github.com/CloudNativeWorks/elchi-backend/controller/dependency"
 	"github.com/CloudNativeWorks/elchi-backend/controller/handlers"
 	"github.com/CloudNativeWorks/elchi-backend/controller/service"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/config"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/drafts"
 	server "github.com/CloudNativeWorks/elchi-backend/pkg/httpserver"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/registry"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/trash"
 )
 
 // restCmd represents the command for starting the REST API server.
@@ -72,6 +90,18 @@ var restCmd = &cobra.Command{
 		}
 
 		appContext := db.NewMongoDB(appConfig, false)
+		appContext.Invalidation = invalidation.NewBus(appContext.Client)
+		appContext.Invalidation.Start(context.Background())
+		if err := references.EnsureIndexes(context.Background(), appContext.Client); err != nil {
+			rootLogger.Errorf("Failed to create references indexes: %v", err)
+		}
+		if err := drafts.EnsureIndexes(context.Background(), appContext.Client); err != nil {
+			rootLogger.Errorf("Failed to create drafts indexes: %v", err)
+		}
+		if err := trash.EnsureIndexes(context.Background(), appContext.Client); err != nil {
+			rootLogger.Errorf("Failed to create trash indexes: %v", err)
+		}
+
 		xdsHandler := xds.NewXDSHandler(appContext)
 		extensionHandler := extension.NewExtensionHandler(appContext)
 		scenarioHandler := scenario.NewScenarioHandler(appContext)
@@ -79,21 +109,35 @@ var restCmd = &cobra.Command{
 		bridgeHandler := bridge.NewBridgeHandler(appContext)
 		userHandler := auth.NewUserHandler(appContext)
 		dependencyHandler := dependency.NewDependencyHandler(appContext)
+		revisionHandler := revision.NewRevisionHandler(appContext, xdsHandler, extensionHandler)
+		changeSetHandler := changeset.NewChangeSetHandler(appContext, xdsHandler, extensionHandler)
+		draftHandler := draft.NewDraftHandler(appContext, xdsHandler, extensionHandler)
+		bundleHandler := bundle.NewBundleHandler(appContext, xdsHandler, extensionHandler)
+		upgradeHandler := upgrade.NewUpgradeHandler(appContext, bundleHandler)
+		renameHandler := rename.NewRenameHandler(appContext, xdsHandler, extensionHandler)
+		cloneHandler := clone.NewCloneHandler(appContext, bundleHandler)
+		searchHandler := search.NewSearchHandler(appContext)
+		relabelHandler := relabel.NewRelabelHandler(appContext)
+		trashHandler := trashbin.NewTrashHandler(appContext, xdsHandler)
+		cascadeHandler := cascade.NewCascadeHandler(appContext, xdsHandler)
+		schemaHandler := schemas.NewSchemaHandler(appContext)
+		lintHandler := lints.NewLintHandler(appContext)
 
 		serviceHandler := service.NewServiceHandler(appContext)
 		clientHandler := client.NewClientHandler(appContext, xdsHandler)
-		
+
 		// Pass registry client to client handler
 		clientHandler.SetRegistryClient(registryClient)
-		
+
 		// Start health monitor for registry connection recovery
 		registryClient.StartHealthMonitor(func() []string {
 			return clientHandler.Service.GetConnectedClientIDs()
 		})
-		
+
 		go clientHandler.Start(appConfig)
 
 		dependencyHandler.StartCacheCleanup(1 * time.Minute)
+		trashHandler.StartRetention(1 * time.Hour)
 
 		h := handlers.NewHandler(
 			xdsHandler,
@@ -105,6 +149,19 @@ var restCmd = &cobra.Command{
 			scenarioHandler,
 			clientHandler,
 			serviceHandler,
+			revisionHandler,
+			changeSetHandler,
+			draftHandler,
+			bundleHandler,
+			upgradeHandler,
+			renameHandler,
+			cloneHandler,
+			searchHandler,
+			relabelHandler,
+			trashHandler,
+			cascadeHandler,
+			schemaHandler,
+			lintHandler,
 		)
 
 		r := router.InitRouter(h)
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/config"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+)
+
+// rebuildReferencesCmd rebuilds the references collection from the stored resources.
+// Downstream lookups use the downstream filters until it has been run once.
+var rebuildReferencesCmd = &cobra.Command{
+	Use:   "rebuild-references",
+	Short: "Rebuild the resource references index",
+	Long:  `Rebuild the resource references index`,
+	Run: func(_ *cobra.Command, _ []string) {
+		appConfig := config.Read(cfgFile)
+
+		if err := logger.Init(logger.Config{
+			Level:      appConfig.Logging.Level,
+			Format:     appConfig.Logging.Format,
+			OutputPath: appConfig.Logging.OutputPath,
+			Module:     "root",
+		}); err != nil {
+			log.Fatalf("Fatal: Logger could not be initialized: %v\n", err)
+		}
+
+		rootLogger := logger.NewLogger("references")
+		appContext := db.NewMongoDB(appConfig, false)
+
+		total, err := resources.RebuildReferences(context.Background(), appContext, rootLogger.Logger)
+		if err != nil {
+			rootLogger.Fatalf("Failed to rebuild references: %v", err)
+		}
+
+		rootLogger.Infof("references index rebuilt with %d references", total)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rebuildReferencesCmd)
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/bridge"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/config"
+)
+
+// bridgeAuth gates the controller facing bridge services. Envoy facing
+// services (ads, vhds, health) on the same server are passed through.
+type bridgeAuth struct {
+	token      string
+	mtls       bool
+	authActive bool
+}
+
+func newBridgeAuth(cfg *config.AppConfig, mtls bool) *bridgeAuth {
+	return &bridgeAuth{
+		token:      cfg.ElchiBridgeToken,
+		mtls:       mtls,
+		authActive: cfg.ElchiBridgeToken != "" || mtls,
+	}
+}
+
+func (a *bridgeAuth) authorize(ctx context.Context, fullMethod string) error {
+	if !a.authActive || !strings.HasPrefix(fullMethod, bridge.ServicePrefix) {
+		return nil
+	}
+
+	if a.mtls && hasVerifiedClientCert(ctx) {
+		return nil
+	}
+
+	if a.token != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(bridge.TokenMetadataKey)
+		if len(values) == 0 {
+			return status.Error(codes.Unauthenticated, "missing bridge token")
+		}
+		if err := bridge.VerifyToken(a.token, values[0], time.Now()); err != nil {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil
+	}
+
+	return status.Error(codes.Unauthenticated, "client certificate required")
+}
+
+func (a *bridgeAuth) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := a.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *bridgeAuth) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func hasVerifiedClientCert(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return false
+	}
+
+	return len(tlsInfo.State.VerifiedChains) > 0
+}
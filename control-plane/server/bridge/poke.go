@@ -37,11 +37,25 @@ func (pss *PokeServiceServer) Poke(ctx context.Context, req *bridge.PokeRequest)
 		return nil, err
 	}
 
-	err = pss.context.SetSnapshot(ctx, allResources, pss.Logger.Logger)
+	diff, err := pss.context.SetSnapshot(ctx, allResources, pss.Logger.Logger)
 	if err != nil {
 		return nil, err
 	}
-	response := &bridge.PokeResponse{Message: "Poke successful"}
+
+	response := &bridge.PokeResponse{
+		Message: "Poke successful",
+		Version: diff.Version,
+		Changes: make([]*bridge.ResourceChanges, 0, len(diff.Changes)),
+	}
+
+	for _, change := range diff.Changes {
+		response.Changes = append(response.Changes, &bridge.ResourceChanges{
+			Type:     change.Type,
+			Added:    change.Added,
+			Modified: change.Modified,
+			Removed:  change.Removed,
+		})
+	}
 
 	return response, nil
 }
@@ -84,7 +98,7 @@ func (ps *PokeService) GetResourceSetSnapshot(ctx context.Context, node, project
 		return err
 	}
 
-	err = ps.Snapshot.SetSnapshot(ctx, allResource, ps.Logger.Logger)
+	_, err = ps.Snapshot.SetSnapshot(ctx, allResource, ps.Logger.Logger)
 	if err != nil {
 		ps.Logger.Warnf("%s", err)
 	}
@@ -1,11 +1,13 @@
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
@@ -50,17 +52,23 @@ func NewServer(xdsServer server.Server, port uint, context *snapshot.Context) *S
 }
 
 // Run starts an xDS server at the given port.
+// Bridge services are served on ELCHI_BRIDGE_PORT when it is set, otherwise next to ads.
 func (s *Server) Run(db *db.AppContext) {
-	var grpcOptions []grpc.ServerOption
-	grpcOptions = append(grpcOptions,
-		grpc.MaxConcurrentStreams(grpcMaxConcurrentStreams),
-		grpc.KeepaliveParams(keepalive.ServerParameters{
-			Time:    grpcKeepaliveTime,
-			Timeout: grpcKeepaliveTimeout,
-		}),
-		grpc.MaxRecvMsgSize(grpcMaxRecvMsgSize),
-		grpc.MaxSendMsgSize(grpcMaxSendMsgSize),
-	)
+	separateBridge := db.Config.ElchiBridgePort != ""
+	grpcOptions := serverOptions()
+	if !separateBridge {
+		auth := newBridgeAuth(db.Config, false)
+		if !auth.authActive {
+			s.logger.Warn("ELCHI_BRIDGE_TOKEN is not set, bridge services are not authenticated")
+		}
+		if db.Config.ElchiBridgeCAFile != "" || db.Config.ElchiBridgeCertFile != "" {
+			s.logger.Warn("ELCHI_BRIDGE_PORT is not set, the bridge certificate and CA are ignored")
+		}
+		grpcOptions = append(grpcOptions,
+			grpc.ChainUnaryInterceptor(auth.unaryInterceptor),
+			grpc.ChainStreamInterceptor(auth.streamInterceptor),
+		)
+	}
 	grpcServer := grpc.NewServer(grpcOptions...)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
@@ -68,7 +76,12 @@ func (s *Server) Run(db *db.AppContext) {
 		s.logger.Fatal(err)
 	}
 
-	s.registerServer(grpcServer, db)
+	s.registerServer(grpcServer)
+	if separateBridge {
+		go s.runBridge(db)
+	} else {
+		s.registerBridge(grpcServer, db)
+	}
 
 	reflection.Register(grpcServer)
 	s.logger.Infof("Management server listening on :%d\n", s.port)
@@ -77,18 +90,68 @@ func (s *Server) Run(db *db.AppContext) {
 	}
 }
 
-func (s *Server) registerServer(grpcServer *grpc.Server, db *db.AppContext) {
+func (s *Server) runBridge(db *db.AppContext) {
+	tlsConfig, err := bridge.ServerTLSConfig(db.Config)
+	if err != nil {
+		s.logger.Fatal(err)
+	}
+
+	grpcOptions := serverOptions()
+	mtls := false
+	if tlsConfig != nil {
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		mtls = tlsConfig.ClientAuth == tls.RequireAndVerifyClientCert
+	}
+
+	auth := newBridgeAuth(db.Config, mtls)
+	if !auth.authActive {
+		s.logger.Warn("neither ELCHI_BRIDGE_TOKEN nor ELCHI_BRIDGE_CA_FILE is set, bridge services are not authenticated")
+	}
+	grpcOptions = append(grpcOptions,
+		grpc.ChainUnaryInterceptor(auth.unaryInterceptor),
+		grpc.ChainStreamInterceptor(auth.streamInterceptor),
+	)
+	grpcServer := grpc.NewServer(grpcOptions...)
+
+	lis, err := net.Listen("tcp", ":"+db.Config.ElchiBridgePort)
+	if err != nil {
+		s.logger.Fatal(err)
+	}
+
+	s.registerBridge(grpcServer, db)
+
+	s.logger.Infof("Bridge server listening on :%s (tls: %t, mtls: %t)\n", db.Config.ElchiBridgePort, tlsConfig != nil, mtls)
+	if err = grpcServer.Serve(lis); err != nil {
+		s.logger.Fatal(err)
+	}
+}
+
+func serverOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.MaxConcurrentStreams(grpcMaxConcurrentStreams),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    grpcKeepaliveTime,
+			Timeout: grpcKeepaliveTimeout,
+		}),
+		grpc.MaxRecvMsgSize(grpcMaxRecvMsgSize),
+		grpc.MaxSendMsgSize(grpcMaxSendMsgSize),
+	}
+}
+
+func (s *Server) registerServer(grpcServer *grpc.Server) {
 	// envoy ads & vhds services
 	discoverygrpc.RegisterAggregatedDiscoveryServiceServer(grpcServer, s.xdsServer)
 	routeservice.RegisterVirtualHostDiscoveryServiceServer(grpcServer, s.xdsServer)
 
-	// bridge grpc services
-	bridge.RegisterSnapshotServiceServer(grpcServer, serverBridge.NewSnapshotServiceServer(s.context))
-	bridge.RegisterResourceServiceServer(grpcServer, serverBridge.NewResourceServiceServer(s.context))
-	bridge.RegisterPokeServiceServer(grpcServer, serverBridge.NewPokeServiceServer(s.context, db))
-
 	// health check
 	grpc_health_v1.RegisterHealthServer(grpcServer, s.healthServer)
 	s.healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 	s.logger.Info("Health check server registered and serving status set to SERVING")
 }
+
+func (s *Server) registerBridge(grpcServer *grpc.Server, db *db.AppContext) {
+	// bridge grpc services
+	bridge.RegisterSnapshotServiceServer(grpcServer, serverBridge.NewSnapshotServiceServer(s.context))
+	bridge.RegisterResourceServiceServer(grpcServer, serverBridge.NewResourceServiceServer(s.context))
+	bridge.RegisterPokeServiceServer(grpcServer, serverBridge.NewPokeServiceServer(s.context, db))
+}
@@ -0,0 +1,80 @@
+package snapshot
+
+import (
+	"sort"
+
+	"github.com/CloudNativeWorks/versioned-go-control-plane/pkg/cache/v3"
+	"github.com/CloudNativeWorks/versioned-go-control-plane/pkg/resource/v3"
+)
+
+var diffTypes = []struct {
+	name    string
+	typeURL resource.Type
+}{
+	{"Listener", resource.ListenerType},
+	{"Cluster", resource.ClusterType},
+	{"Endpoint", resource.EndpointType},
+	{"Route", resource.RouteType},
+	{"Virtual Host", resource.VirtualHostType},
+	{"Extension", resource.ExtensionConfigType},
+	{"Secret", resource.SecretType},
+}
+
+// DiffSnapshots compares resource names and version hashes of two snapshots per type.
+// A nil previous snapshot reports every resource of next as added.
+func DiffSnapshots(previous, next cache.ResourceSnapshot) *SnapshotDiff {
+	diff := &SnapshotDiff{}
+	if next == nil {
+		return diff
+	}
+	diff.Version = next.GetVersion(resource.ListenerType)
+
+	for _, dt := range diffTypes {
+		nextVersions := versionMap(next, dt.typeURL)
+		prevVersions := map[string]string{}
+		if previous != nil {
+			prevVersions = versionMap(previous, dt.typeURL)
+		}
+
+		change := ResourceDiff{Type: dt.name}
+		for name, hash := range nextVersions {
+			prevHash, exists := prevVersions[name]
+			switch {
+			case !exists:
+				change.Added = append(change.Added, name)
+			case prevHash != hash:
+				change.Modified = append(change.Modified, name)
+			}
+		}
+		for name := range prevVersions {
+			if _, exists := nextVersions[name]; !exists {
+				change.Removed = append(change.Removed, name)
+			}
+		}
+
+		if len(change.Added) == 0 && len(change.Modified) == 0 && len(change.Removed) == 0 {
+			continue
+		}
+
+		sort.Strings(change.Added)
+		sort.Strings(change.Modified)
+		sort.Strings(change.Removed)
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	return diff
+}
+
+func versionMap(snap cache.ResourceSnapshot, typeURL resource.Type) map[string]string {
+	versions := snap.GetVersionMap(typeURL)
+	if versions != nil {
+		return versions
+	}
+
+	// version map is not constructed, fall back to resource names only
+	versions = map[string]string{}
+	for name := range snap.GetResources(typeURL) {
+		versions[name] = ""
+	}
+	return versions
+}
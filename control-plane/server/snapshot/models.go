@@ -11,3 +11,15 @@ type Cache struct {
 type Context struct {
 	Cache *Cache
 }
+
+type ResourceDiff struct {
+	Type     string
+	Added    []string
+	Modified []string
+	Removed  []string
+}
+
+type SnapshotDiff struct {
+	Version string
+	Changes []ResourceDiff
+}
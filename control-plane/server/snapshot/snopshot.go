@@ -35,23 +35,29 @@ func GetContext() *Context {
 	return ctx
 }
 
-func (c *Context) SetSnapshot(ctx context.Context, resources *xdsResource.AllResources, logger *logrus.Logger) error {
+func (c *Context) SetSnapshot(ctx context.Context, resources *xdsResource.AllResources, logger *logrus.Logger) (*SnapshotDiff, error) {
 	if resources == nil {
-		return fmt.Errorf("resources cannot be nil")
+		return nil, fmt.Errorf("resources cannot be nil")
 	}
 
 	snapshot := GenerateSnapshot(resources)
 	if snapshot == nil {
-		return fmt.Errorf("failed to generate snapshot")
+		return nil, fmt.Errorf("failed to generate snapshot")
+	}
+
+	// the previous snapshot is missing on the first poke of a node, then everything counts as added
+	previous, err := c.Cache.Cache.GetSnapshot(resources.NodeID)
+	if err != nil {
+		previous = nil
 	}
 
 	if err := c.Cache.Cache.SetSnapshot(ctx, resources.NodeID, snapshot); err != nil {
 		logger.Errorf("Failed to set snapshot for nodeID %s: %v", resources.NodeID, err)
-		return err
+		return nil, err
 	}
 
 	logger.Infof("Successfully set snapshot for nodeID: %s", resources.NodeID)
-	return nil
+	return DiffSnapshots(previous, snapshot), nil
 }
 
 func GenerateSnapshot(r *xdsResource.AllResources) *cache.Snapshot {
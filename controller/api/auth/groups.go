@@ -168,6 +168,11 @@ func (handler *AppHandler) SetUpdateGroup(c *gin.Context) {
 		return
 	}
 
+	if err := validateLabelScopes(groupWA.LabelScopes); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
 	if groupWA.IsCreate {
 		status, msg, groupID = handler.CreateGroup(ctx, userCollection, groupWA)
 	} else {
@@ -235,6 +240,10 @@ func (handler *AppHandler) UpdateGroup(ctx context.Context, groupCollection *mon
 		updateMap["members"] = groupWA.Members
 	}
 
+	if groupWA.LabelScopes != nil {
+		updateMap["label_scopes"] = groupWA.LabelScopes
+	}
+
 	updateMap["updated_at"] = primitive.NewDateTimeFromTime(time.Now())
 	result, err := groupCollection.UpdateOne(ctx, filter, update)
 	if err != nil {
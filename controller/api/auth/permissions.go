@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -10,6 +12,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/labels"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 )
 
@@ -20,15 +24,28 @@ func (handler *AppHandler) GetPermissions(c *gin.Context) {
 	ctx := c.Request.Context()
 	project := c.Query("project")
 	userOrGroup := c.Param("kind")
-	filter := bson.M{"general.project": project}
 
+	selector, err := labels.Parse(c.Query("selector"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	projectFilter := func() bson.M {
+		filter := bson.M{"general.project": project}
+		if len(selector) > 0 {
+			filter["$and"] = bson.A{selector.Filter(labels.Field)}
+		}
+		return filter
+	}
+
+	filter := projectFilter()
 	if userOrGroup == "users" {
 		filter["general.permissions.users"] = c.Param("id")
 	} else {
 		filter["general.permissions.groups"] = c.Param("id")
 	}
 
-	all, err := handler.GetData(ctx, bson.M{"general.project": project}, c.Param("type"))
+	all, err := handler.GetData(ctx, projectFilter(), c.Param("type"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
@@ -41,9 +58,125 @@ func (handler *AppHandler) GetPermissions(c *gin.Context) {
 	}
 
 	records := map[string]any{"all": all, "selected": selected}
+	if userOrGroup == "groups" {
+		scoped, err := handler.getScopedData(ctx, c.Param("id"), c.Param("type"), projectFilter())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+		records["scoped"] = scoped
+	}
 	c.JSON(http.StatusOK, records)
 }
 
+// getScopedData lists the resources of the collection the label scopes of the group
+// grant, they are not part of the selected resources since they follow the labels.
+func (handler *AppHandler) getScopedData(ctx context.Context, groupID, collection string, filter bson.M) ([]bson.M, error) {
+	objectID, err := primitive.ObjectIDFromHex(groupID)
+	if err != nil {
+		return []bson.M{}, nil
+	}
+
+	var group models.Group
+	err = handler.Context.Client.Collection("groups").FindOne(ctx, bson.M{"_id": objectID}).Decode(&group)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return []bson.M{}, nil
+		}
+		return nil, err
+	}
+
+	var scopes bson.A
+	for _, scope := range group.LabelScopes {
+		if scope.Collection != "" && scope.Collection != collection {
+			continue
+		}
+		selector, err := labels.Parse(scope.Selector)
+		if err != nil || len(selector) == 0 {
+			continue
+		}
+		scopes = append(scopes, selector.Filter(labels.Field))
+	}
+	if len(scopes) == 0 {
+		return []bson.M{}, nil
+	}
+
+	filter["$or"] = scopes
+	return handler.GetData(ctx, filter, collection)
+}
+
+// LabelScopes loads the label scopes the groups of the user hold in the project of the
+// request for the permission filter, owners and admins see every resource and skip it.
+func (handler *AppHandler) LabelScopes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+		isOwner, _ := c.Get("isOwner")
+		if owner, _ := isOwner.(bool); owner {
+			c.Next()
+			return
+		}
+		if r, ok := role.(*models.Role); ok && r != nil && *r == models.RoleAdmin {
+			c.Next()
+			return
+		}
+
+		project := c.Query("project")
+		groups, _ := c.Get("groups")
+		groupIDs, ok := groups.(*[]string)
+		if !ok || groupIDs == nil || len(*groupIDs) == 0 || project == "" {
+			c.Next()
+			return
+		}
+
+		objectIDs := make([]primitive.ObjectID, 0, len(*groupIDs))
+		for _, id := range *groupIDs {
+			if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+				objectIDs = append(objectIDs, objectID)
+			}
+		}
+
+		filter := bson.M{"_id": bson.M{"$in": objectIDs}, "project": project, "label_scopes.0": bson.M{"$exists": true}}
+		opts := options.Find().SetProjection(bson.M{"label_scopes": 1})
+		cursor, err := handler.Context.Client.Collection("groups").Find(c.Request.Context(), filter, opts)
+		if err != nil {
+			handler.Logger.Warnf("could not load label scopes: %v", err)
+			c.Next()
+			return
+		}
+
+		var found []models.Group
+		if err := cursor.All(c.Request.Context(), &found); err != nil {
+			handler.Logger.Warnf("could not decode label scopes: %v", err)
+			c.Next()
+			return
+		}
+
+		var scopes []models.LabelScope
+		for _, group := range found {
+			scopes = append(scopes, group.LabelScopes...)
+		}
+		c.Set("label_scopes", scopes)
+		c.Next()
+	}
+}
+
+// validateLabelScopes checks the collection and the selector of every label scope.
+func validateLabelScopes(scopes []models.LabelScope) error {
+	for _, scope := range scopes {
+		if scope.Collection != "" && !helper.Contains(models.XDSCollections(), scope.Collection) {
+			return fmt.Errorf("unknown collection in label scope: %s", scope.Collection)
+		}
+		selector, err := labels.Parse(scope.Selector)
+		if err != nil {
+			return err
+		}
+		if len(selector) == 0 {
+			return errors.New("a label scope needs a selector")
+		}
+	}
+	return nil
+}
+
 func (handler *AppHandler) SetPermission(permissions models.Permission, userOrGroupID, kind string) {
 	updatePermissions := func(collection *mongo.Collection, filter, update bson.M, action, name string) {
 		_, err := collection.UpdateMany(context.TODO(), filter, update)
@@ -14,8 +14,13 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/drafts"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/lint"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/trash"
 )
 
 type ProjectWithActiveStatus struct {
@@ -212,6 +217,15 @@ func (handler *AppHandler) CreateProject(ctx context.Context, projectCollection
 		return http.StatusBadRequest, validationErr.Error(), "0"
 	}
 
+	if projectWA.Settings != nil {
+		if err := projectWA.Settings.Validate(); err != nil {
+			return http.StatusBadRequest, err.Error(), "0"
+		}
+		if err := lint.CheckSeverities(projectWA.Settings.Lint); err != nil {
+			return http.StatusBadRequest, err.Error(), "0"
+		}
+	}
+
 	now := time.Now()
 	projectWA.CreatedAt = primitive.NewDateTimeFromTime(now)
 	projectWA.UpdatedAt = primitive.NewDateTimeFromTime(now)
@@ -281,6 +295,15 @@ func (handler *AppHandler) UpdateProject(ctx context.Context, projectCollection
 	if projectWA.Members != nil {
 		setMap["members"] = projectWA.Members
 	}
+	if projectWA.Settings != nil {
+		if err := projectWA.Settings.Validate(); err != nil {
+			return http.StatusBadRequest, err.Error()
+		}
+		if err := lint.CheckSeverities(projectWA.Settings.Lint); err != nil {
+			return http.StatusBadRequest, err.Error()
+		}
+		setMap["settings"] = projectWA.Settings
+	}
 
 	setMap["updated_at"] = primitive.NewDateTimeFromTime(time.Now())
 	result, err := projectCollection.UpdateOne(ctx, filter, update)
@@ -419,6 +442,17 @@ func (handler *AppHandler) DeleteProject(c *gin.Context) {
 		}
 	}
 
+	if err := references.RemoveProject(ctx, handler.Context.Client, projectID); err != nil {
+		handler.Logger.Errorf("Error deleting references of project %s: %v", projectID, err)
+	}
+	if err := drafts.RemoveProject(ctx, handler.Context.Client, projectID); err != nil {
+		handler.Logger.Errorf("%v", err)
+	}
+	if err := trash.RemoveProject(ctx, handler.Context.Client, projectID); err != nil {
+		handler.Logger.Errorf("%v", err)
+	}
+	handler.Context.Invalidation.Publish(invalidation.Event{Project: projectID})
+
 	_, err = projectsCollection.DeleteOne(ctx, bson.M{"_id": objectID})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "Project could not be deleted"})
@@ -51,6 +51,45 @@ var AllowedEndpoints = []string{
 	"/api/v3/custom/count/filters",
 	"/api/v3/custom/count/all",
 	"/api/v3/dependency/:name",
+	"/api/v3/dependency/export",
+	"/api/v3/dependency/impact/:name",
+	"/api/v3/dependency/orphans",
+	"/api/v3/revisions",
+	"/api/v3/revisions/diff",
+	"/api/v3/revisions/:revision_id",
+	"/api/v3/revisions/:revision_id/rollback",
+	"/api/v3/changesets",
+	"/api/v3/changesets/:changeset_id",
+	"/api/v3/changesets/:changeset_id/operations",
+	"/api/v3/changesets/:changeset_id/operations/:index",
+	"/api/v3/changesets/:changeset_id/validate",
+	"/api/v3/changesets/:changeset_id/commit",
+	"/api/v3/drafts",
+	"/api/v3/drafts/publish",
+	"/api/v3/drafts/:draft_id",
+	"/api/v3/bundle/export",
+	"/api/v3/bundle/import",
+	"/api/v3/bundle/import/preview",
+	"/api/v3/bundle/envoy/import",
+	"/api/v3/bundle/envoy/import/preview",
+	"/api/v3/upgrade",
+	"/api/v3/upgrade/plan",
+	"/api/v3/rename/:name",
+	"/api/v3/clone/:name",
+	"/api/v3/clone/:name/preview",
+	"/api/v3/search",
+	"/api/v3/labels",
+	"/api/v3/labels/:name",
+	"/api/v3/trash",
+	"/api/v3/trash/:id",
+	"/api/v3/trash/:id/restore",
+	"/api/v3/cascade/:name",
+	"/api/v3/cascade/:name/plan",
+	"/api/v3/schema",
+	"/api/v3/schema/openapi",
+	"/api/v3/lint",
+	"/api/v3/lint/rules",
+	"/api/v3/lint/:name",
 	"/api/v3/bridge/stats/:name",
 	"/api/v3/bridge/poke/:name",
 	"/api/v3/bridge/snapshot_details",
@@ -28,8 +28,8 @@ func InitRouter(h *handlers.Handler) *gin.Engine {
 	api := e.Group("/api")
 	v3 := api.Group("/v3")
 	op := api.Group("/op")
-	v3.Use(middleware.Authentication())
-	op.Use(middleware.Authentication())
+	v3.Use(middleware.Authentication(), h.Auth.LabelScopes())
+	op.Use(middleware.Authentication(), h.Auth.LabelScopes())
 
 	apiAuth := e.Group("/auth")
 	apiSettings := v3.Group("/setting")
@@ -37,6 +37,19 @@ func InitRouter(h *handlers.Handler) *gin.Engine {
 	apiExtension := v3.Group("/eo")
 	apiResource := v3.Group("/xds")
 	apiDependency := v3.Group("/dependency")
+	apiRevision := v3.Group("/revisions")
+	apiChangeSet := v3.Group("/changesets")
+	apiDraft := v3.Group("/drafts")
+	apiBundle := v3.Group("/bundle")
+	apiUpgrade := v3.Group("/upgrade")
+	apiRename := v3.Group("/rename")
+	apiClone := v3.Group("/clone")
+	apiSearch := v3.Group("/search")
+	apiLabels := v3.Group("/labels")
+	apiTrash := v3.Group("/trash")
+	apiCascade := v3.Group("/cascade")
+	apiSchema := v3.Group("/schema")
+	apiLint := v3.Group("/lint")
 	apiScenario := v3.Group("/scenario")
 	apiBridge := v3.Group("/bridge")
 	apiClient := op.Group("/clients")
@@ -49,6 +62,19 @@ func InitRouter(h *handlers.Handler) *gin.Engine {
 	initScenarioRoutes(apiScenario, h)
 	initResourceRoutes(apiResource, h)
 	initDependencyRoutes(apiDependency, h)
+	initRevisionRoutes(apiRevision, h)
+	initChangeSetRoutes(apiChangeSet, h)
+	initDraftRoutes(apiDraft, h)
+	initBundleRoutes(apiBundle, h)
+	initUpgradeRoutes(apiUpgrade, h)
+	initRenameRoutes(apiRename, h)
+	initCloneRoutes(apiClone, h)
+	initSearchRoutes(apiSearch, h)
+	initLabelRoutes(apiLabels, h)
+	initTrashRoutes(apiTrash, h)
+	initCascadeRoutes(apiCascade, h)
+	initSchemaRoutes(apiSchema, h, e)
+	initLintRoutes(apiLint, h)
 	initBridgeRoutes(apiBridge, h)
 	initClientRoutes(apiClient, h)
 	initServiceRoutes(apiService, h)
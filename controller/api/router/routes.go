@@ -137,12 +137,198 @@ func initDependencyRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
 		path    string
 		handler gin.HandlerFunc
 	}{
+		{"GET", "/export", h.ExportProjectDependencies},
+		{"GET", "/impact/:name", h.GetResourceImpact},
+		{"GET", "/orphans", h.GetOrphanReport},
+		{"DELETE", "/orphans", h.DeleteOrphans},
 		{"GET", "/:name", h.GetResourceDependencies},
 	}
 
 	initRoutes(rg, routes)
 }
 
+func initRevisionRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"GET", "", h.ListRevisions},
+		{"GET", "/diff", h.DiffRevisions},
+		{"GET", "/:revision_id", h.GetRevision},
+		{"POST", "/:revision_id/rollback", h.RollbackRevision},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initBundleRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"GET", "/export", h.ExportProject},
+		{"POST", "/import", h.ImportProject},
+		{"POST", "/import/preview", h.PreviewImport},
+		{"POST", "/envoy/import", h.ImportEnvoy},
+		{"POST", "/envoy/import/preview", h.PreviewEnvoyImport},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initUpgradeRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"POST", "", h.UpgradeProject},
+		{"POST", "/plan", h.PlanUpgrade},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initRenameRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"POST", "/:name", h.RenameResource},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initCloneRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"POST", "/:name", h.CloneResource},
+		{"POST", "/:name/preview", h.PreviewClone},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initSearchRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"POST", "", h.SearchResources},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initLabelRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"GET", "", h.ListLabels},
+		{"PUT", "/:name", h.SetLabels},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initTrashRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"GET", "", h.ListTrash},
+		{"POST", "/:id/restore", h.RestoreTrash},
+		{"DELETE", "/:id", h.PurgeTrash},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initCascadeRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"POST", "/:name", h.CascadeDelete},
+		{"POST", "/:name/plan", h.PlanCascadeDelete},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initSchemaRoutes(rg *gin.RouterGroup, h *handlers.Handler, e *gin.Engine) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"GET", "", h.GetGTypeSchema},
+		{"GET", "/openapi", h.GetOpenAPI(e)},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initLintRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"GET", "", h.LintProject},
+		{"GET", "/rules", h.GetLintRules},
+		{"GET", "/:name", h.LintResource},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initDraftRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"GET", "", h.ListDrafts},
+		{"POST", "/publish", h.PublishDrafts},
+		{"GET", "/:draft_id", h.GetDraft},
+		{"DELETE", "/:draft_id", h.DiscardDraft},
+	}
+
+	initRoutes(rg, routes)
+}
+
+func initChangeSetRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
+	routes := []struct {
+		method  string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"GET", "", h.ListChangeSets},
+		{"POST", "", h.OpenChangeSet},
+		{"GET", "/:changeset_id", h.GetChangeSet},
+		{"DELETE", "/:changeset_id", h.DiscardChangeSet},
+		{"POST", "/:changeset_id/operations", h.AddChangeSetOperation},
+		{"DELETE", "/:changeset_id/operations/:index", h.RemoveChangeSetOperation},
+		{"POST", "/:changeset_id/validate", h.ValidateChangeSet},
+		{"POST", "/:changeset_id/commit", h.CommitChangeSet},
+	}
+
+	initRoutes(rg, routes)
+}
+
 func initExtensionRoutes(rg *gin.RouterGroup, h *handlers.Handler) {
 	routes := []struct {
 		method  string
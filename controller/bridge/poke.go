@@ -9,7 +9,7 @@ import (
 	"github.com/CloudNativeWorks/elchi-backend/pkg/bridge"
 )
 
-func PokeNode(ctx context.Context, poke bridge.PokeServiceClient, nodeID, project, version, downstreamAddress string) (any, error) {
+func PokeNode(ctx context.Context, poke bridge.PokeServiceClient, nodeID, project, version, downstreamAddress string) (*bridge.PokeResponse, error) {
 	var nodeid string
 	if downstreamAddress != "" {
 		nodeid = fmt.Sprintf("%s::%s::%s", nodeID, project, downstreamAddress)
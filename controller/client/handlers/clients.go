@@ -8,18 +8,7 @@ import (
 )
 
 func (h *Client) ListClients(ctx context.Context, _ models.OperationClass, requestDetails models.RequestDetails) (any, error) {
-	if requestDetails.WithServiceIPs == "true" {
-		clients, err := h.Service.GetAllClientsWithServiceIPs()
-		if err != nil {
-			return nil, err
-		}
-		return clients, nil
-	}
-	clients, err := h.Service.GetAllClients()
-	if err != nil {
-		return nil, err
-	}
-	return clients, nil
+	return h.Service.ListClients(ctx, requestDetails.List, requestDetails.WithServiceIPs == "true")
 }
 
 func (h *Client) GetClient(ctx context.Context, _ models.OperationClass, requestDetails models.RequestDetails) (any, error) {
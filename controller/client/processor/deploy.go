@@ -3,14 +3,17 @@ package processor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/CloudNativeWorks/elchi-backend/controller/client/services"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/xds"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	pkgservices "github.com/CloudNativeWorks/elchi-backend/pkg/services"
 	client "github.com/CloudNativeWorks/elchi-proto/client"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type DeployProcessor struct {
@@ -30,6 +33,10 @@ func (p *DeployProcessor) ValidateAndTransform(op models.OperationClass, request
 	}
 
 	requestDetails = FillRequestDetails(op, requestDetails, bootstrap)
+	if err := p.checkListenerConflicts(op, bootstrap.General.Version, cl); err != nil {
+		return nil, err
+	}
+
 	op.SetExtend(models.Extend{DownstreamAddress: cl.DownstreamAddress})
 	adminPort, err := resources.GetAdminPortFromBootstrap(bootstrap.Resource.Resource)
 	if err != nil {
@@ -68,3 +75,30 @@ func (p *DeployProcessor) ValidateAndTransform(op models.OperationClass, request
 
 	return deploy, nil
 }
+
+// checkListenerConflicts rejects the deploy when the listener would bind an ip:port on
+// the client which a listener of another service, or of another deploy of the same
+// service on the client, already takes.
+func (p *DeployProcessor) checkListenerConflicts(op models.OperationClass, version string, cl models.ServiceClients) error {
+	name, project := op.GetCommandName(), op.GetCommandProject()
+	db := p.XDSHandler.Context.Client
+
+	var listener models.DBResource
+	filter := bson.M{"general.name": name, "general.project": project, "general.version": version}
+	if err := db.Collection("listeners").FindOne(context.TODO(), filter).Decode(&listener); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		return err
+	}
+
+	var clients []models.ServiceClients
+	for _, existing := range pkgservices.FetchDownstreamAddressFromService(db, name, project, version) {
+		if existing.ClientID == cl.ClientID && existing.DownstreamAddress != cl.DownstreamAddress {
+			clients = append(clients, existing)
+		}
+	}
+	clients = append(clients, cl)
+
+	return pkgservices.CheckListenerConflicts(context.TODO(), db, name, project, version, listener.Resource.Resource, clients)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/pagination"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/registry"
 	pb "github.com/CloudNativeWorks/elchi-proto/client"
 	"github.com/google/uuid"
@@ -177,24 +178,6 @@ func (s *ClientService) GetClient(clientID string) (*client.ClientInfo, error) {
 	return client, nil
 }
 
-// getAllClientsFromDB, tüm client'ları döner.
-func (s *ClientService) getAllClientsFromDB(ctx context.Context) ([]*client.ClientInfo, error) {
-	cursor, err := s.Context.Client.Collection("clients").Find(ctx, bson.M{})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-	var clients []*client.ClientInfo
-	for cursor.Next(ctx) {
-		var c client.ClientInfo
-		if err := cursor.Decode(&c); err != nil {
-			return nil, err
-		}
-		clients = append(clients, &c)
-	}
-	return clients, nil
-}
-
 // GetClientByClientID, returns a single client.
 func (s *ClientService) GetClientByClientID(ctx context.Context, clientID string) (*client.ClientInfo, error) {
 	client := client.ClientInfo{}
@@ -233,15 +216,60 @@ func (s *ClientService) getAllServiceIPsMap(ctx context.Context) (map[string][]s
 	return ipMap, nil
 }
 
-// GetAllClients, tüm client'ları ve ilişkili servis IP'lerini döner.
-func (s *ClientService) GetAllClientsWithServiceIPs() ([]*ClientWithServiceIPs, error) {
-	ctx := context.Background()
+// clientListSpec is the list spec of clients.
+var clientListSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"id":        "_id",
+		"client_id": "client_id",
+		"name":      "name",
+		"hostname":  "hostname",
+		"version":   "version",
+		"connected": "connected",
+		"last_seen": "last_seen",
+	},
+	DefaultSort: "client_id",
+	Fields: map[string]string{
+		"client_id": "client_id",
+		"name":      "name",
+		"hostname":  "hostname",
+		"version":   "version",
+		"os":        "os",
+		"arch":      "arch",
+		"kernel":    "kernel",
+		"connected": "connected",
+		"last_seen": "last_seen",
+		"metadata":  "metadata",
+		"projects":  "projects",
+	},
+	Keep: []string{"client_id"},
+}
+
+// ListClients returns the clients, with the downstream addresses their services
+// use when withServiceIPs is set.
+func (s *ClientService) ListClients(ctx context.Context, list models.ListOptions, withServiceIPs bool) (any, error) {
 	s.clientsMux.RLock()
 	defer s.clientsMux.RUnlock()
 
-	clients, err := s.getAllClientsFromDB(ctx)
+	listed, err := pagination.Find(ctx, s.Context.Client.Collection("clients"), bson.M{}, list, clientListSpec)
 	if err != nil {
-		return nil, fmt.Errorf("clients find error: %w", err)
+		return nil, fmt.Errorf("failed to get clients: %w", err)
+	}
+
+	clients := make([]*client.ClientInfo, 0, len(listed.Records))
+	for _, record := range listed.Records {
+		var c client.ClientInfo
+		bsonBytes, err := bson.Marshal(record)
+		if err == nil {
+			err = bson.Unmarshal(bsonBytes, &c)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode client: %w", err)
+		}
+		clients = append(clients, &c)
+	}
+
+	if !withServiceIPs {
+		return listed.Wrap(clients), nil
 	}
 
 	ipMap, err := s.getAllServiceIPsMap(ctx)
@@ -249,36 +277,14 @@ func (s *ClientService) GetAllClientsWithServiceIPs() ([]*ClientWithServiceIPs,
 		return nil, fmt.Errorf("services aggregate error: %w", err)
 	}
 
-	var results []*ClientWithServiceIPs
+	results := make([]*ClientWithServiceIPs, 0, len(clients))
 	for _, c := range clients {
-		ips := ipMap[c.ClientID]
 		results = append(results, &ClientWithServiceIPs{
 			ClientInfo: c,
-			ServiceIPs: ips,
+			ServiceIPs: ipMap[c.ClientID],
 		})
 	}
-	return results, nil
-}
-
-// GetAllClients returns all connected clients
-func (s *ClientService) GetAllClients() ([]*client.ClientInfo, error) {
-	s.clientsMux.RLock()
-	defer s.clientsMux.RUnlock()
-	cursor, err := s.Context.Client.Collection("clients").Find(context.Background(), bson.M{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get clients: %v", err)
-	}
-	defer cursor.Close(context.Background())
-
-	result := []*client.ClientInfo{}
-	for cursor.Next(context.Background()) {
-		var client client.ClientInfo
-		if err := cursor.Decode(&client); err != nil {
-			return nil, fmt.Errorf("failed to decode client: %v", err)
-		}
-		result = append(result, &client)
-	}
-	return result, nil
+	return listed.Wrap(results), nil
 }
 
 // ValidateSession validates client session
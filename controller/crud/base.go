@@ -2,11 +2,22 @@ package crud
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/CloudNativeWorks/elchi-backend/controller/poker"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/bridge"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/drafts"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/lint"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
 )
 
 type Application struct {
@@ -15,9 +26,69 @@ type Application struct {
 	ResourceService *bridge.ResourceServiceClient
 }
 
+// InTransaction runs a write with the records kept next to it, such as its references
+// or its trash record, in one transaction, or in the transaction of ctx when the caller
+// already started one.
+func InTransaction(ctx context.Context, context *db.AppContext, writeFunc func(sc context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return writeFunc(ctx)
+	}
+
+	session, err := context.Client.Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("could not start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, writeFunc(sc)
+	})
+	return err
+}
+
+type dryRunKey struct{}
+
+// WithDryRun marks the writes made with the context as a dry run whose transaction is
+// aborted, the handlers skip what they do outside the database.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// Invalidate publishes the invalidation of a written resource, a dry run has nothing
+// to invalidate.
+func Invalidate(ctx context.Context, context *db.AppContext, event invalidation.Event) {
+	if IsDryRun(ctx) {
+		return
+	}
+	context.Invalidation.Publish(event)
+}
+
+// RecordRevision keeps a copy of the written resource, a failure does not fail the write.
+func RecordRevision(ctx context.Context, context *db.AppContext, operation string, resource models.ResourceClass, user models.UserDetails) {
+	if err := revisions.Record(ctx, context.Client, operation, resource, user); err != nil {
+		context.Logger.Errorf("%v", err)
+	}
+}
+
+// WithWarnings adds the unresolved references and the lint findings of a saved resource to the response.
+func WithWarnings(response map[string]any, warnings []resources.DanglingReference, findings []lint.Finding) map[string]any {
+	if len(warnings) > 0 {
+		response["warnings"] = warnings
+	}
+	if len(findings) > 0 {
+		response["lint"] = findings
+	}
+	return response
+}
+
 func HandleResourceChange(ctx context.Context, resource models.ResourceClass, requestDetails models.RequestDetails, context *db.AppContext, project string, poke *bridge.PokeServiceClient) *poker.Processed {
-	if requestDetails.SaveOrPublish == "publish" {
-		initialProcessed := poker.Processed{Listeners: []string{}, Depends: []string{}}
+	if requestDetails.SaveOrPublish == models.SavePublish {
+		initialProcessed := poker.Processed{Listeners: []string{}, Depends: []string{}, Nodes: []poker.NodeChanges{}}
 		changedResources := poker.DetectChangedResource(
 			ctx,
 			resource.GetGeneral().GType,
@@ -33,3 +104,27 @@ func HandleResourceChange(ctx context.Context, resource models.ResourceClass, re
 	}
 	return nil
 }
+
+// PublishChanges pokes the listeners affected by several resources with one shared
+// Processed, so a listener reached from more than one resource is pushed once.
+func PublishChanges(ctx context.Context, context *db.AppContext, resources []models.ResourceClass, poke *bridge.PokeServiceClient) *poker.Processed {
+	processed := poker.Processed{Listeners: []string{}, Depends: []string{}, Nodes: []poker.NodeChanges{}}
+	for _, resource := range resources {
+		general := resource.GetGeneral()
+		poker.DetectChangedResource(ctx, general.GType, general.Version, general.Name, general.Project, context, &processed, poke, resource.GetManaged())
+	}
+	return &processed
+}
+
+// SaveDraft stores an update as the draft of the live resource matched by filter
+// instead of writing it, the live resource stays untouched until the draft is published.
+func SaveDraft(ctx context.Context, context *db.AppContext, collection *mongo.Collection, filter bson.M, resource models.ResourceClass, baseVersion int, user models.UserDetails) (*drafts.Draft, error) {
+	var live struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := collection.FindOne(ctx, filter).Decode(&live); err != nil {
+		return nil, err
+	}
+
+	return drafts.Save(ctx, context.Client, resource, live.ID, strconv.Itoa(baseVersion), user)
+}
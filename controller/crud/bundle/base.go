@@ -0,0 +1,110 @@
+package bundle
+
+import (
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/extension"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/xds"
+	"github.com/CloudNativeWorks/elchi-backend/controller/poker"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+)
+
+const (
+	APIVersion = "elchi.io/bundle/v1"
+	Kind       = "ProjectBundle"
+)
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Import actions reported by the preview and the import result.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionSkip   = "skip"
+)
+
+// Conflict policies for resources which already exist in the target project.
+const (
+	OnConflictUpdate = "update"
+	OnConflictSkip   = "skip"
+	OnConflictFail   = "fail"
+)
+
+type AppHandler struct {
+	Context   *db.AppContext
+	XDS       *xds.AppHandler
+	Extension *extension.AppHandler
+	Logger    *logger.Logger
+}
+
+func NewBundleHandler(context *db.AppContext, xdsHandler *xds.AppHandler, extensionHandler *extension.AppHandler) *AppHandler {
+	return &AppHandler{
+		Context:   context,
+		XDS:       xdsHandler,
+		Extension: extensionHandler,
+		Logger:    logger.NewLogger("controller/bundle"),
+	}
+}
+
+// Bundle is the portable form of one version of a project. Resources keep the
+// envoy shaped body and only the parts of general which are not installation specific.
+type Bundle struct {
+	APIVersion string     `json:"api_version"`
+	Kind       string     `json:"kind"`
+	ExportedAt string     `json:"exported_at"`
+	Project    string     `json:"project"`
+	Version    string     `json:"version"`
+	Resources  []Resource `json:"resources"`
+	Services   []Service  `json:"services,omitempty"`
+}
+
+type Resource struct {
+	Collection string         `json:"collection"`
+	General    models.General `json:"general"`
+	Resource   any            `json:"resource"`
+	// Redacted marks secrets exported without their key material.
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// Service is a service of an exported listener. The admin port is the one of the
+// source, an import allocates its own.
+type Service struct {
+	Name      string `json:"name"`
+	AdminPort uint32 `json:"admin_port"`
+}
+
+// ExportOptions of a bundle export, secrets lose their key material unless
+// IncludeSecretKeys is set.
+type ExportOptions struct {
+	IncludeSecretKeys bool
+}
+
+type ImportOptions struct {
+	OnConflict    string
+	SaveOrPublish string
+}
+
+type ImportItem struct {
+	Collection string                `json:"collection"`
+	Name       string                `json:"name"`
+	GType      models.GTypes         `json:"gtype"`
+	Action     string                `json:"action"`
+	Conflict   bool                  `json:"conflict,omitempty"`
+	Reason     string                `json:"reason,omitempty"`
+	Unresolved []references.Endpoint `json:"unresolved,omitempty"`
+	Error      string                `json:"error,omitempty"`
+}
+
+type ImportResult struct {
+	Project   string           `json:"project"`
+	Version   string           `json:"version"`
+	Preview   bool             `json:"preview"`
+	Items     []ImportItem     `json:"items"`
+	Services  []string         `json:"services,omitempty"`
+	Warnings  []string         `json:"warnings,omitempty"`
+	Published *poker.Processed `json:"published,omitempty"`
+}
@@ -0,0 +1,489 @@
+package bundle
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const redactedValue = "[redacted]"
+
+// envoyConverter splits envoy configuration into elchi resources the way the UI
+// stores them: filters and transport sockets become resources of their own and the
+// parent keeps a config_discovery or typed_config reference to them.
+type envoyConverter struct {
+	bundle *Bundle
+	names  map[string]bool
+	// inline holds the route configurations and endpoints split out of their parent,
+	// a config dump lists them again among the static resources.
+	inline   map[string]bool
+	warnings []string
+}
+
+// ParseEnvoy reads an envoy /config_dump or a static bootstrap, YAML or JSON, into a
+// bundle which is imported like an exported one. The returned warnings list the
+// parts of the configuration which could not be carried over.
+func ParseEnvoy(data []byte) (*Bundle, []string, error) {
+	var document map[string]any
+	if err := decodeDocument(data, &document); err != nil {
+		return nil, nil, fmt.Errorf("could not parse envoy config: %w", err)
+	}
+
+	converter := &envoyConverter{
+		bundle:   &Bundle{APIVersion: APIVersion, Kind: Kind, Resources: []Resource{}},
+		names:    make(map[string]bool),
+		inline:   make(map[string]bool),
+		warnings: []string{},
+	}
+
+	switch {
+	case document["configs"] != nil:
+		converter.configDump(asList(document["configs"]))
+	case document["static_resources"] != nil:
+		converter.bootstrap(asMap(document["static_resources"]))
+	default:
+		return nil, nil, errors.New("neither a config_dump nor a bootstrap with static_resources")
+	}
+
+	if len(converter.bundle.Resources) == 0 {
+		return nil, converter.warnings, errors.New("no resources found in envoy config")
+	}
+	return converter.bundle, converter.warnings, nil
+}
+
+func (c *envoyConverter) configDump(configs []any) {
+	for _, entry := range configs {
+		config := asMap(entry)
+		switch configType := anyType(config); configType {
+		case "envoy.admin.v3.ListenersConfigDump":
+			for _, item := range asList(config["static_listeners"]) {
+				c.addNamed(models.Listener, asMap(asMap(item)["listener"]))
+			}
+			for _, item := range asList(config["dynamic_listeners"]) {
+				state := asMap(asMap(item)["active_state"])
+				if state == nil {
+					state = asMap(asMap(item)["warming_state"])
+				}
+				c.addNamed(models.Listener, asMap(state["listener"]))
+			}
+		case "envoy.admin.v3.ClustersConfigDump":
+			for _, field := range []string{"static_clusters", "dynamic_active_clusters", "dynamic_warming_clusters"} {
+				for _, item := range asList(config[field]) {
+					c.addNamed(models.Cluster, asMap(asMap(item)["cluster"]))
+				}
+			}
+		case "envoy.admin.v3.RoutesConfigDump":
+			for _, field := range []string{"static_route_configs", "dynamic_route_configs"} {
+				for _, item := range asList(config[field]) {
+					c.addNamed(models.Route, asMap(asMap(item)["route_config"]))
+				}
+			}
+		case "envoy.admin.v3.EndpointsConfigDump":
+			for _, field := range []string{"static_endpoint_configs", "dynamic_endpoint_configs"} {
+				for _, item := range asList(config[field]) {
+					c.addNamed(models.Endpoint, asMap(asMap(item)["endpoint_config"]))
+				}
+			}
+		case "envoy.admin.v3.SecretsConfigDump":
+			for _, field := range []string{"static_secrets", "dynamic_active_secrets", "dynamic_warming_secrets"} {
+				for _, item := range asList(config[field]) {
+					c.addSecret(asMap(asMap(item)["secret"]))
+				}
+			}
+		case "envoy.admin.v3.BootstrapConfigDump":
+			// static resources of the bootstrap are listed in the other dumps as well
+		default:
+			c.warn("config dump section %s is not imported", configType)
+		}
+	}
+}
+
+func (c *envoyConverter) bootstrap(static map[string]any) {
+	for _, item := range asList(static["listeners"]) {
+		c.addNamed(models.Listener, asMap(item))
+	}
+	for _, item := range asList(static["clusters"]) {
+		c.addNamed(models.Cluster, asMap(item))
+	}
+	for _, item := range asList(static["secrets"]) {
+		c.addSecret(asMap(item))
+	}
+}
+
+// addNamed adds a top level envoy resource under its own name.
+func (c *envoyConverter) addNamed(gtype models.GTypes, value map[string]any) {
+	if value == nil {
+		return
+	}
+
+	nameField := "name"
+	if gtype == models.Endpoint {
+		nameField = "cluster_name"
+	}
+	name, _ := value[nameField].(string)
+	if name == "" {
+		c.warn("%s without a name is skipped", gtype.PrettyName())
+		return
+	}
+	if !c.claim(gtype.CollectionString(), name) {
+		if c.inline[gtype.CollectionString()+"/"+name] {
+			return
+		}
+		c.warn("%s/%s is listed more than once, the first one is imported", gtype.CollectionString(), name)
+		return
+	}
+
+	body := withoutType(value)
+	if gtype == models.Cluster {
+		c.splitLoadAssignment(body, name)
+		prepareCluster(body, name)
+	}
+	c.add(name, gtype, body)
+}
+
+// addSecret adds an envoy Secret as the elchi secret resource of its oneof field.
+func (c *envoyConverter) addSecret(secret map[string]any) {
+	if secret == nil {
+		return
+	}
+	name, _ := secret["name"].(string)
+	for _, kind := range envoySecrets {
+		body := asMap(secret[kind.Field])
+		if body == nil {
+			continue
+		}
+		if name == "" || !c.claim(kind.GType.CollectionString(), name) {
+			c.warn("secret %q is skipped, it has no name or is listed more than once", name)
+			return
+		}
+		c.add(name, kind.GType, body)
+		return
+	}
+	c.warn("secret %q has no supported type and is skipped", name)
+}
+
+// add converts the body and appends it to the bundle, wrapped in a list for gtypes
+// stored as one.
+func (c *envoyConverter) add(name string, gtype models.GTypes, body map[string]any) {
+	kind := envoyKinds[gtype]
+	general := models.General{
+		Name:            name,
+		Type:            models.KnownTYPES(kind.Type),
+		GType:           gtype,
+		Collection:      gtype.CollectionString(),
+		CanonicalName:   kind.CanonicalName,
+		Category:        kind.Category,
+		Metadata:        map[string]any{"imported_from": "envoy"},
+		ConfigDiscovery: []*models.ConfigDiscovery{},
+	}
+
+	switch gtype {
+	case models.DownstreamTLSContext, models.UpstreamTLSContext:
+		c.splitSecrets(body, name)
+	case models.HTTPConnectionManager:
+		c.splitRouteConfig(body, name)
+	}
+
+	var resource any = c.walk(body, &general)
+	if gtype.StoredAsList() {
+		resource = []any{resource}
+	}
+
+	c.bundle.Resources = append(c.bundle.Resources, Resource{
+		Collection: general.Collection,
+		General:    general,
+		Resource:   resource,
+		Redacted:   general.Collection == "secrets" && containsRedacted(body),
+	})
+}
+
+// walk rewrites envoy Any values into references to split out resources and points
+// every config source to ADS, owner collects the config discoveries of the resource.
+func (c *envoyConverter) walk(value any, owner *models.General) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, child := range typed {
+			switch key {
+			case "typed_config":
+				if config := asMap(child); config != nil && config["@type"] != nil {
+					if reference, ok := c.reference(config, owner); ok {
+						typed[key] = reference
+					} else {
+						delete(typed, key)
+					}
+					continue
+				}
+			case "typed_extension_protocol_options":
+				options := asMap(child)
+				for option, config := range options {
+					if reference, ok := c.reference(asMap(config), owner); ok {
+						options[option] = reference
+					} else {
+						delete(options, option)
+					}
+				}
+				continue
+			case "typed_per_filter_config":
+				c.warn("%s/%s: typed_per_filter_config is not imported, per route filter configs have to be added again", owner.Collection, owner.Name)
+				delete(typed, key)
+				continue
+			case "filters", "http_filters":
+				if list, ok := child.([]any); ok {
+					typed[key] = c.filters(list, owner)
+					continue
+				}
+			case "config_source", "eds_config", "sds_config":
+				typed[key] = adsConfigSource()
+				continue
+			}
+			typed[key] = c.walk(child, owner)
+		}
+		return typed
+	case []any:
+		for i, child := range typed {
+			typed[i] = c.walk(child, owner)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// filters splits network and http filters into resources referenced through config_discovery.
+func (c *envoyConverter) filters(list []any, owner *models.General) []any {
+	for i, item := range list {
+		filter := asMap(item)
+		config := asMap(filter["typed_config"])
+		gtype := anyType(config)
+		kind, known := envoyKinds[gtype]
+		if config == nil || !known || !kind.Discovery {
+			if filter != nil && filter["config_discovery"] != nil {
+				c.warn("%s/%s: filter %v already uses config discovery, its resource has to be added manually", owner.Collection, owner.Name, filter["name"])
+			}
+			list[i] = c.walk(item, owner)
+			continue
+		}
+
+		name := c.extract(gtype, config, owner.Name)
+		delete(filter, "typed_config")
+		filter["name"] = name
+		filter["config_discovery"] = map[string]any{
+			"config_source": adsConfigSource(),
+			"type_urls":     []any{gtype.String()},
+		}
+		owner.ConfigDiscovery = append(owner.ConfigDiscovery, &models.ConfigDiscovery{
+			ParentName:    name,
+			GType:         gtype,
+			Name:          name,
+			Priority:      i,
+			Category:      kind.Category,
+			CanonicalName: kind.CanonicalName,
+		})
+	}
+	return list
+}
+
+// reference splits an envoy Any into a resource and returns the typed_config which
+// points to it, unsupported types are dropped with a warning.
+func (c *envoyConverter) reference(config map[string]any, owner *models.General) (map[string]any, bool) {
+	gtype := anyType(config)
+	kind, known := envoyKinds[gtype]
+	if !known {
+		c.warn("%s/%s: %s is not supported by the importer and is left out", owner.Collection, owner.Name, gtype)
+		return nil, false
+	}
+
+	name := c.extract(gtype, config, owner.Name)
+	value, err := json.Marshal(models.TypedConfig{
+		Name:          name,
+		CanonicalName: kind.CanonicalName,
+		Gtype:         gtype,
+		Type:          kind.Type,
+		Category:      kind.Category,
+		Collection:    gtype.CollectionString(),
+	})
+	if err != nil {
+		c.warn("%s/%s: %s could not be referenced: %v", owner.Collection, owner.Name, gtype, err)
+		return nil, false
+	}
+
+	return map[string]any{
+		"type_url": gtype.String(),
+		"value":    base64.StdEncoding.EncodeToString(value),
+	}, true
+}
+
+func (c *envoyConverter) extract(gtype models.GTypes, config map[string]any, ownerName string) string {
+	name := c.uniqueName(gtype.CollectionString(), ownerName+"-"+envoyKinds[gtype].Short)
+	c.add(name, gtype, withoutType(config))
+	return name
+}
+
+// splitSecrets moves inline certificates and validation contexts of a tls context to
+// secret resources served over SDS.
+func (c *envoyConverter) splitSecrets(tlsContext map[string]any, name string) {
+	common := asMap(tlsContext["common_tls_context"])
+	if common == nil {
+		return
+	}
+
+	if certificates := asList(common["tls_certificates"]); certificates != nil {
+		configs := asList(common["tls_certificate_sds_secret_configs"])
+		for _, certificate := range certificates {
+			secretName := c.uniqueName("secrets", name+"-"+envoyKinds[models.TLSCertificate].Short)
+			c.add(secretName, models.TLSCertificate, asMap(certificate))
+			configs = append(configs, sdsSecretConfig(secretName))
+		}
+		delete(common, "tls_certificates")
+		common["tls_certificate_sds_secret_configs"] = configs
+	}
+
+	if validation := asMap(common["validation_context"]); validation != nil {
+		secretName := c.uniqueName("secrets", name+"-"+envoyKinds[models.CertificateValidationContext].Short)
+		c.add(secretName, models.CertificateValidationContext, validation)
+		delete(common, "validation_context")
+		common["validation_context_sds_secret_config"] = sdsSecretConfig(secretName)
+	}
+}
+
+// splitRouteConfig moves the inline route configuration of an http connection manager
+// to a route resource served over RDS.
+func (c *envoyConverter) splitRouteConfig(hcm map[string]any, name string) {
+	routeConfig := asMap(hcm["route_config"])
+	if routeConfig == nil {
+		return
+	}
+
+	base, _ := routeConfig["name"].(string)
+	if base == "" {
+		base = name + "-" + envoyKinds[models.Route].Short
+	}
+	routeName := c.uniqueName(models.Route.CollectionString(), base)
+	routeConfig["name"] = routeName
+	c.inline[models.Route.CollectionString()+"/"+routeName] = true
+	c.add(routeName, models.Route, routeConfig)
+
+	delete(hcm, "route_config")
+	hcm["rds"] = map[string]any{"config_source": adsConfigSource(), "route_config_name": routeName}
+}
+
+// splitLoadAssignment moves the inline endpoints of a static cluster to an endpoint
+// resource and turns the cluster into an EDS cluster. DNS clusters resolve the hosts
+// of their load_assignment, they keep it inline.
+func (c *envoyConverter) splitLoadAssignment(cluster map[string]any, name string) {
+	assignment := asMap(cluster["load_assignment"])
+	if assignment == nil || cluster["cluster_type"] != nil {
+		return
+	}
+	if clusterType, _ := cluster["type"].(string); clusterType != "" && clusterType != "STATIC" {
+		return
+	}
+
+	endpointName := c.uniqueName(models.Endpoint.CollectionString(), name)
+	assignment["cluster_name"] = endpointName
+	c.inline[models.Endpoint.CollectionString()+"/"+endpointName] = true
+	c.add(endpointName, models.Endpoint, assignment)
+
+	delete(cluster, "load_assignment")
+	cluster["type"] = "EDS"
+	cluster["eds_cluster_config"] = map[string]any{"service_name": endpointName}
+}
+
+// prepareCluster makes EDS clusters name their endpoints resource explicitly.
+func prepareCluster(cluster map[string]any, name string) {
+	if cluster["type"] != "EDS" {
+		return
+	}
+	eds := asMap(cluster["eds_cluster_config"])
+	if eds == nil {
+		eds = map[string]any{}
+		cluster["eds_cluster_config"] = eds
+	}
+	if service, _ := eds["service_name"].(string); service == "" {
+		eds["service_name"] = name
+	}
+	eds["eds_config"] = adsConfigSource()
+}
+
+func (c *envoyConverter) claim(collection, name string) bool {
+	key := collection + "/" + name
+	if c.names[key] {
+		return false
+	}
+	c.names[key] = true
+	return true
+}
+
+func (c *envoyConverter) uniqueName(collection, base string) string {
+	name := base
+	for i := 2; !c.claim(collection, name); i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	return name
+}
+
+func (c *envoyConverter) warn(format string, args ...any) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+func adsConfigSource() map[string]any {
+	return map[string]any{
+		"ads":                   map[string]any{},
+		"initial_fetch_timeout": "2.0s",
+		"resource_api_version":  "V3",
+	}
+}
+
+func sdsSecretConfig(name string) map[string]any {
+	return map[string]any{"name": name, "sds_config": adsConfigSource()}
+}
+
+// anyType returns the gtype of an envoy Any, without the type url prefix.
+func anyType(value map[string]any) models.GTypes {
+	typeURL, _ := value["@type"].(string)
+	return models.GTypes(strings.TrimPrefix(typeURL, models.APITypePrefix.String()))
+}
+
+func withoutType(value map[string]any) map[string]any {
+	body := make(map[string]any, len(value))
+	for key, child := range value {
+		if key != "@type" {
+			body[key] = child
+		}
+	}
+	return body
+}
+
+func containsRedacted(value any) bool {
+	switch typed := value.(type) {
+	case map[string]any:
+		for _, child := range typed {
+			if containsRedacted(child) {
+				return true
+			}
+		}
+	case []any:
+		for _, child := range typed {
+			if containsRedacted(child) {
+				return true
+			}
+		}
+	case string:
+		return typed == redactedValue
+	}
+	return false
+}
+
+func asMap(value any) map[string]any {
+	typed, _ := value.(map[string]any)
+	return typed
+}
+
+func asList(value any) []any {
+	typed, _ := value.([]any)
+	return typed
+}
@@ -0,0 +1,79 @@
+package bundle
+
+import "github.com/CloudNativeWorks/elchi-backend/pkg/models"
+
+// envoyKind holds the general fields the UI sets for a resource of a gtype.
+type envoyKind struct {
+	Type          string
+	Category      string
+	CanonicalName string
+	// Short names resources split out of a parent, e.g. <listener>-hcm.
+	Short string
+	// Discovery filters are referenced through config_discovery (ECDS) instead of typed_config.
+	Discovery bool
+}
+
+var envoyKinds = map[models.GTypes]envoyKind{
+	models.Listener: {Type: "listener", Category: "listener", CanonicalName: "config.listener.v3.Listener"},
+	models.Cluster:  {Type: "cluster", Category: "cluster", CanonicalName: "config.cluster.v3.Cluster"},
+	models.Endpoint: {Type: "endpoint", Category: "cluster", CanonicalName: "config.endpoint.v3.Endpoint"},
+	models.Route:    {Type: "route", Category: "route", CanonicalName: "config.route.v3.RouteConfiguration", Short: "route"},
+
+	models.TLSCertificate:               {Type: "secret", Category: "secret", CanonicalName: "extensions.transport_sockets.tls.v3.TlsCertificate", Short: "cert"},
+	models.CertificateValidationContext: {Type: "secret", Category: "secret", CanonicalName: "extensions.transport_sockets.tls.v3.CertificateValidationContext", Short: "validation"},
+	models.TLSSessionTicketKeys:         {Type: "secret", Category: "secret", CanonicalName: "extensions.transport_sockets.tls.v3.TlsSessionTicketKeys", Short: "ticket-keys"},
+	models.GenericSecret:                {Type: "secret", Category: "secret", CanonicalName: "extensions.transport_sockets.tls.v3.GenericSecret", Short: "secret"},
+
+	models.DownstreamTLSContext: {Type: "secret", Category: "envoy.transport_sockets.tls", CanonicalName: "envoy.transport_sockets.downstream", Short: "tls"},
+	models.UpstreamTLSContext:   {Type: "secret", Category: "envoy.transport_sockets.tls", CanonicalName: "envoy.transport_sockets.upstream", Short: "tls"},
+
+	models.HTTPConnectionManager: {Type: "network_filter", Category: "envoy.filters.network", CanonicalName: "envoy.filters.network.http_connection_manager", Short: "hcm", Discovery: true},
+	models.TCPProxy:              {Type: "network_filter", Category: "envoy.filters.network", CanonicalName: "envoy.filters.network.tcp_proxy", Short: "tcp-proxy", Discovery: true},
+	models.RBAC:                  {Type: "network_filter", Category: "envoy.filters.network", CanonicalName: "envoy.filters.network.rbac", Short: "rbac", Discovery: true},
+	models.ConnectionLimit:       {Type: "network_filter", Category: "envoy.filters.network", CanonicalName: "envoy.filters.network.connection_limit", Short: "connection-limit", Discovery: true},
+	models.NetworkLocalRatelimit: {Type: "network_filter", Category: "envoy.filters.network", CanonicalName: "envoy.filters.network.local_ratelimit", Short: "local-ratelimit", Discovery: true},
+
+	models.Router:              {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.router", Short: "router", Discovery: true},
+	models.HTTPRBAC:            {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.rbac", Short: "rbac", Discovery: true},
+	models.BasicAuth:           {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.basic_auth", Short: "basic-auth", Discovery: true},
+	models.Cors:                {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.cors", Short: "cors", Discovery: true},
+	models.BandwidthLimit:      {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.bandwidth_limit", Short: "bandwidth-limit", Discovery: true},
+	models.Compressor:          {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.compressor", Short: "compressor", Discovery: true},
+	models.Lua:                 {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.lua", Short: "lua", Discovery: true},
+	models.Buffer:              {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.buffer", Short: "buffer", Discovery: true},
+	models.AdaptiveConcurrency: {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.adaptive_concurrency", Short: "adaptive-concurrency", Discovery: true},
+	models.AdmissionControl:    {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.admission_control", Short: "admission-control", Discovery: true},
+	models.StatefulSession:     {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.stateful_session", Short: "stateful-session", Discovery: true},
+	models.CsrfPolicy:          {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.csrf", Short: "csrf", Discovery: true},
+	models.HTTPLocalRatelimit:  {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.local_ratelimit", Short: "local-ratelimit", Discovery: true},
+	models.OAuth2:              {Type: "http_filter", Category: "envoy.filters.http", CanonicalName: "envoy.filters.http.oauth2", Short: "oauth2", Discovery: true},
+
+	models.ListenerTLSInspector:   {Type: "listener_filter", Category: "envoy.filters.listener", CanonicalName: "envoy.filters.listener.tls_inspector", Short: "tls-inspector"},
+	models.ListenerHTTPInspector:  {Type: "listener_filter", Category: "envoy.filters.listener", CanonicalName: "envoy.filters.listener.http_inspector", Short: "http-inspector"},
+	models.ListenerOriginalDst:    {Type: "listener_filter", Category: "envoy.filters.listener", CanonicalName: "envoy.filters.listener.original_dst", Short: "original-dst"},
+	models.ListenerOriginalSrc:    {Type: "listener_filter", Category: "envoy.filters.listener", CanonicalName: "envoy.filters.listener.original_src", Short: "original-src"},
+	models.ListenerProxyProtocol:  {Type: "listener_filter", Category: "envoy.filters.listener", CanonicalName: "envoy.filters.listener.proxy_protocol", Short: "proxy-protocol"},
+	models.ListenerLocalRatelimit: {Type: "listener_filter", Category: "envoy.filters.listener", CanonicalName: "envoy.filters.listener.local_ratelimit", Short: "local-ratelimit"},
+
+	models.FileAccessLog:    {Type: "access_log", Category: "envoy.access_loggers", CanonicalName: "envoy.access_loggers.file", Short: "access-log"},
+	models.StdoutAccessLog:  {Type: "access_log", Category: "envoy.access_loggers", CanonicalName: "envoy.access_loggers.stdout", Short: "access-log"},
+	models.StdErrAccessLog:  {Type: "access_log", Category: "envoy.access_loggers", CanonicalName: "envoy.access_loggers.stderr", Short: "access-log"},
+	models.FluentdAccessLog: {Type: "access_log", Category: "envoy.access_loggers", CanonicalName: "envoy.access_loggers.fluentd", Short: "access-log"},
+
+	models.HTTPProtocolOptions:      {Type: "http_protocol_options", Category: "envoy.upstreams.http.http_protocol_options", CanonicalName: "envoy.upstreams.http.http_protocol_options", Short: "hpo"},
+	models.GzipCompressor:           {Type: "compressor_library", Category: "envoy.compression.compressor", CanonicalName: "envoy.compression.gzip.compressor", Short: "gzip"},
+	models.BrotliCompressor:         {Type: "compressor_library", Category: "envoy.compression.compressor", CanonicalName: "envoy.compression.brotli.compressor", Short: "brotli"},
+	models.ZstdCompressor:           {Type: "compressor_library", Category: "envoy.compression.compressor", CanonicalName: "envoy.compression.zstd.compressor", Short: "zstd"},
+	models.HealthCheckEventFileSink: {Type: "hcefs", Category: "envoy.health_check.event_sinks", CanonicalName: "envoy.health_check.event_sink.file", Short: "hcefs"},
+}
+
+// envoySecrets maps the oneof fields of an envoy Secret to the gtype of its elchi resource.
+var envoySecrets = []struct {
+	Field string
+	GType models.GTypes
+}{
+	{"tls_certificate", models.TLSCertificate},
+	{"validation_context", models.CertificateValidationContext},
+	{"session_ticket_keys", models.TLSSessionTicketKeys},
+	{"generic_secret", models.GenericSecret},
+}
@@ -0,0 +1,163 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+)
+
+// secretKeyFields hold key material inside envoy secrets, they are dropped unless
+// an export includes secret keys.
+var secretKeyFields = []string{"private_key", "password", "secret", "keys", "pkcs12"}
+
+// Export collects every resource of one version of a project the user can read,
+// sorted by collection and name so exports of the same state are identical.
+func (bh *AppHandler) Export(ctx context.Context, requestDetails models.RequestDetails, exportOptions ExportOptions) (*Bundle, error) {
+	if requestDetails.Project == "" || requestDetails.Version == "" {
+		return nil, errors.New("project and version are required")
+	}
+
+	bundle := &Bundle{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Project:    requestDetails.Project,
+		Version:    requestDetails.Version,
+		Resources:  []Resource{},
+	}
+
+	listeners := []string{}
+	for _, collection := range models.XDSCollections() {
+		// bootstraps belong to the installation, importing a listener creates its own
+		if collection == "bootstrap" {
+			continue
+		}
+		exported, err := bh.exportCollection(ctx, collection, requestDetails, exportOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range exported {
+			if collection == "listeners" {
+				listeners = append(listeners, resource.General.Name)
+			}
+		}
+		bundle.Resources = append(bundle.Resources, exported...)
+	}
+
+	services, err := bh.exportServices(ctx, requestDetails.Project, listeners)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Services = services
+
+	return bundle, nil
+}
+
+func (bh *AppHandler) exportCollection(ctx context.Context, collection string, requestDetails models.RequestDetails, exportOptions ExportOptions) ([]Resource, error) {
+	details := requestDetails
+	details.Collection = collection
+	filter := common.AddUserFilter(details, bson.M{"general.version": requestDetails.Version})
+	opts := options.Find().SetSort(bson.D{{Key: "general.name", Value: 1}})
+
+	cursor, err := bh.Context.Client.Collection(collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+
+	exported := []Resource{}
+	for cursor.Next(ctx) {
+		var raw bson.M
+		if err := cursor.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("could not decode %s document: %w", collection, err)
+		}
+
+		resource, err := resources.DecodeDBResource(raw)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert %s document: %w", collection, err)
+		}
+
+		item := Resource{
+			Collection: collection,
+			General:    portableGeneral(resource.General, collection),
+			Resource:   resource.Resource.Resource,
+		}
+		if collection == "secrets" && !exportOptions.IncludeSecretKeys {
+			item.Resource = redact(item.Resource)
+			item.Redacted = true
+		}
+		exported = append(exported, item)
+	}
+
+	return exported, cursor.Err()
+}
+
+// exportServices returns the services of the exported listeners without their
+// clients, those belong to the installation.
+func (bh *AppHandler) exportServices(ctx context.Context, project string, listeners []string) ([]Service, error) {
+	if len(listeners) == 0 {
+		return nil, nil
+	}
+
+	filter := bson.M{"project": project, "name": bson.M{"$in": listeners}}
+	cursor, err := bh.Context.Client.Collection("services").Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("could not read services: %w", err)
+	}
+
+	var stored []models.Service
+	if err := cursor.All(ctx, &stored); err != nil {
+		return nil, fmt.Errorf("could not decode services: %w", err)
+	}
+
+	services := make([]Service, 0, len(stored))
+	for _, service := range stored {
+		services = append(services, Service{Name: service.Name, AdminPort: service.AdminPort})
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+	return services, nil
+}
+
+// portableGeneral drops the fields which are set again by the importing installation.
+func portableGeneral(general models.General, collection string) models.General {
+	general.Project = ""
+	general.Collection = collection
+	general.Permissions = models.Permissions{}
+	general.TypedConfig = nil
+	general.CreatedAt = 0
+	general.UpdatedAt = 0
+	general.UpdatedBy = ""
+	return general
+}
+
+func redact(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(typed))
+		for key, child := range typed {
+			if slices.Contains(secretKeyFields, key) {
+				continue
+			}
+			result[key] = redact(child)
+		}
+		return result
+	case []any:
+		result := make([]any, len(typed))
+		for i, child := range typed {
+			result[i] = redact(child)
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,318 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/yaml.v3"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+)
+
+// plannedResource is a bundle resource remapped to the target project with the
+// action the import takes for it.
+type plannedResource struct {
+	item        *ImportItem
+	resource    *models.DBResource
+	resourceID  primitive.ObjectID
+	liveVersion string
+}
+
+// Parse reads a YAML or JSON bundle, JSON being valid YAML.
+func Parse(data []byte) (*Bundle, error) {
+	var bundle Bundle
+	if err := decodeDocument(data, &bundle); err != nil {
+		return nil, fmt.Errorf("could not parse bundle: %w", err)
+	}
+
+	if bundle.APIVersion != APIVersion || bundle.Kind != Kind {
+		return nil, fmt.Errorf("unsupported bundle %s %s, expected %s %s", bundle.APIVersion, bundle.Kind, APIVersion, Kind)
+	}
+	return &bundle, nil
+}
+
+// decodeDocument reads YAML or JSON into target through its json names.
+func decodeDocument(data []byte, target any) error {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, target)
+}
+
+// Import writes the bundle into the project of the request, remapping project and
+// version. Resources are created or updated by name in dependency order inside one
+// transaction. With preview set nothing is written and the planned actions are returned.
+func (bh *AppHandler) Import(ctx context.Context, requestDetails models.RequestDetails, bundle *Bundle, importOptions ImportOptions, preview bool) (*ImportResult, error) {
+	if requestDetails.Project == "" {
+		return nil, errors.New("project is required")
+	}
+
+	version := requestDetails.Version
+	if version == "" {
+		version = bundle.Version
+	}
+	if version == "" {
+		return nil, errors.New("version is required")
+	}
+
+	if importOptions.OnConflict == "" {
+		importOptions.OnConflict = OnConflictUpdate
+	}
+	if !helper.Contains([]string{OnConflictUpdate, OnConflictSkip, OnConflictFail}, importOptions.OnConflict) {
+		return nil, fmt.Errorf("unknown conflict policy: %s", importOptions.OnConflict)
+	}
+
+	plan, err := bh.plan(ctx, bundle, requestDetails.Project, version, importOptions.OnConflict)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Project: requestDetails.Project, Version: version, Preview: preview, Items: make([]ImportItem, 0, len(plan))}
+	for _, planned := range plan {
+		result.Items = append(result.Items, *planned.item)
+	}
+
+	if preview {
+		return result, nil
+	}
+
+	for _, planned := range plan {
+		if planned.item.Error != "" {
+			return result, fmt.Errorf("%s/%s: %s", planned.item.Collection, planned.item.Name, planned.item.Error)
+		}
+		if planned.item.Conflict && importOptions.OnConflict == OnConflictFail {
+			return result, fmt.Errorf("%s/%s already exists", planned.item.Collection, planned.item.Name)
+		}
+	}
+
+	written, err := bh.apply(ctx, plan, bundle.Services, requestDetails, result)
+	if err != nil {
+		return result, err
+	}
+
+	if importOptions.SaveOrPublish == models.SavePublish {
+		result.Published = crud.PublishChanges(ctx, bh.Context, written, bh.XDS.PokeService)
+	}
+	return result, nil
+}
+
+func (bh *AppHandler) plan(ctx context.Context, bundle *Bundle, project, version, onConflict string) ([]*plannedResource, error) {
+	inBundle := make(map[references.Endpoint]bool, len(bundle.Resources))
+	for _, item := range bundle.Resources {
+		inBundle[references.Endpoint{Collection: item.Collection, Name: item.General.Name}] = true
+	}
+
+	ordered := orderByDependencies(bundle.Resources, bh.Logger.Logger)
+	plan := make([]*plannedResource, 0, len(ordered))
+	exists := make(map[references.Endpoint]bool)
+
+	for _, item := range ordered {
+		general := item.General
+		general.Project = project
+		general.Version = version
+		general.Collection = item.Collection
+		general.TypedConfig = nil
+
+		planned := &plannedResource{
+			item:     &ImportItem{Collection: item.Collection, Name: general.Name, GType: general.GType},
+			resource: &models.DBResource{General: general, Resource: models.Resource{Version: "1", Resource: item.Resource}},
+		}
+
+		live, err := bh.findLive(ctx, item.Collection, general.Name, project, version)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case item.Collection == "bootstrap":
+			// a bootstrap holds the node id, control plane address and admin port of the
+			// source, the listener gets its own when it is created
+			planned.item.Action = ActionSkip
+			planned.item.Reason = "bootstraps are generated for the listeners of the target project"
+		case live != nil:
+			planned.resourceID = live.ID
+			planned.liveVersion = live.Version
+			planned.item.Conflict = true
+			planned.item.Action = ActionUpdate
+			if onConflict == OnConflictSkip {
+				planned.item.Action = ActionSkip
+				planned.item.Reason = "already exists"
+			}
+			if item.Redacted {
+				planned.item.Action = ActionSkip
+				planned.item.Reason = "secret keys were excluded from the bundle, the existing secret is kept"
+			}
+		case item.Redacted:
+			planned.item.Action = ActionCreate
+			planned.item.Error = "secret keys were excluded from the bundle"
+		default:
+			planned.item.Action = ActionCreate
+		}
+
+		for _, endpoint := range resources.ReferencedEndpoints(planned.resource, bh.Logger.Logger) {
+			key := references.Endpoint{Collection: endpoint.Collection, Name: endpoint.Name}
+			if inBundle[key] {
+				continue
+			}
+			found, checked := exists[key]
+			if !checked {
+				target, err := bh.findLive(ctx, endpoint.Collection, endpoint.Name, project, version)
+				if err != nil {
+					return nil, err
+				}
+				found = target != nil
+				exists[key] = found
+			}
+			if !found {
+				planned.item.Unresolved = append(planned.item.Unresolved, endpoint)
+			}
+		}
+
+		plan = append(plan, planned)
+	}
+
+	return plan, nil
+}
+
+type liveResource struct {
+	ID      primitive.ObjectID
+	Version string
+}
+
+func (bh *AppHandler) findLive(ctx context.Context, collection, name, project, version string) (*liveResource, error) {
+	filter := bson.M{"general.name": name, "general.project": project, "general.version": version}
+	opts := options.FindOne().SetProjection(bson.M{"resource.version": 1})
+
+	var found struct {
+		ID       primitive.ObjectID `bson:"_id"`
+		Resource struct {
+			Version string `bson:"version"`
+		} `bson:"resource"`
+	}
+	if err := bh.Context.Client.Collection(collection).FindOne(ctx, filter, opts).Decode(&found); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &liveResource{ID: found.ID, Version: found.Resource.Version}, nil
+}
+
+// apply runs the plan in one transaction, a failing resource leaves the target untouched.
+func (bh *AppHandler) apply(ctx context.Context, plan []*plannedResource, services []Service, requestDetails models.RequestDetails, result *ImportResult) ([]models.ResourceClass, error) {
+	session, err := bh.Context.Client.Client().StartSession()
+	if err != nil {
+		return nil, fmt.Errorf("could not start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	var written []models.ResourceClass
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		written = written[:0]
+		for i, planned := range plan {
+			if planned.item.Action == ActionSkip {
+				continue
+			}
+			if err := bh.applyResource(sc, planned, requestDetails); err != nil {
+				result.Items[i].Error = err.Error()
+				return nil, fmt.Errorf("%s/%s: %w", planned.item.Collection, planned.item.Name, err)
+			}
+			written = append(written, planned.resource)
+		}
+
+		imported, err := bh.importServices(sc, services, requestDetails.Project)
+		if err != nil {
+			return nil, err
+		}
+		result.Services = imported
+		return nil, nil
+	})
+
+	return written, err
+}
+
+func (bh *AppHandler) applyResource(ctx context.Context, planned *plannedResource, requestDetails models.RequestDetails) error {
+	general := planned.resource.General
+	details := requestDetails
+	details.Name = general.Name
+	details.Collection = general.Collection
+	details.Version = general.Version
+	details.GType = general.GType
+	details.CanonicalName = general.CanonicalName
+	details.IfMatch = ""
+	details.SaveOrPublish = models.SaveLive
+
+	isExtension := helper.Contains([]string{"filters", "extensions"}, general.Collection)
+
+	if planned.item.Action == ActionCreate {
+		planned.resource.Resource.Version = "1"
+		var err error
+		if isExtension {
+			_, err = bh.Extension.SetExtension(ctx, planned.resource, details)
+		} else {
+			_, err = bh.XDS.SetResource(ctx, planned.resource, details)
+		}
+		return err
+	}
+
+	planned.resource.ID = planned.resourceID
+	planned.resource.Resource.Version = planned.liveVersion
+	details.ResourceID = planned.resourceID.Hex()
+
+	var err error
+	if isExtension {
+		_, err = bh.Extension.UpdateExtensions(ctx, planned.resource, details)
+	} else {
+		_, err = bh.XDS.UpdateResource(ctx, planned.resource, details)
+	}
+	return err
+}
+
+// importServices adds the services which do not exist yet with an admin port of the
+// target project, existing services keep their admin port and clients.
+func (bh *AppHandler) importServices(ctx context.Context, services []Service, project string) ([]string, error) {
+	imported := []string{}
+	collection := bh.Context.Client.Collection("services")
+	for _, service := range services {
+		err := collection.FindOne(ctx, bson.M{"name": service.Name, "project": project}).Err()
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("could not read service %s: %w", service.Name, err)
+		}
+
+		adminPort, err := crud.GetNextAdminPort(ctx, bh.Context.Client, service.Name, project)
+		if err != nil {
+			return nil, fmt.Errorf("could not allocate admin port of service %s: %w", service.Name, err)
+		}
+
+		_, err = collection.InsertOne(ctx, models.Service{
+			Name:      service.Name,
+			Project:   project,
+			AdminPort: uint32(adminPort),
+			Clients:   []models.ListenerClient{},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not import service %s: %w", service.Name, err)
+		}
+		imported = append(imported, service.Name)
+	}
+	return imported, nil
+}
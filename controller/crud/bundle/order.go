@@ -0,0 +1,76 @@
+package bundle
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+)
+
+// orderByDependencies sorts the resources so every resource comes after the bundle
+// resources it references, keeping the bundle order otherwise. Bootstraps of older
+// bundles go last, the import skips them. Resources in a reference cycle keep
+// their bundle order at the end.
+func orderByDependencies(items []Resource, logger *logrus.Logger) []Resource {
+	keyOf := func(item Resource) references.Endpoint {
+		return references.Endpoint{Collection: item.Collection, Name: item.General.Name}
+	}
+
+	inBundle := make(map[references.Endpoint]bool, len(items))
+	for _, item := range items {
+		inBundle[keyOf(item)] = true
+	}
+
+	depends := make([][]references.Endpoint, len(items))
+	for i, item := range items {
+		resource := &models.DBResource{General: item.General, Resource: models.Resource{Resource: item.Resource}}
+		for _, endpoint := range resources.ReferencedEndpoints(resource, logger) {
+			key := references.Endpoint{Collection: endpoint.Collection, Name: endpoint.Name}
+			if inBundle[key] && key != keyOf(item) {
+				depends[i] = append(depends[i], key)
+			}
+		}
+	}
+
+	ordered := make([]Resource, 0, len(items))
+	placed := make(map[references.Endpoint]bool, len(items))
+	done := make([]bool, len(items))
+
+	for progress := true; progress; {
+		progress = false
+		for i, item := range items {
+			if done[i] || item.Collection == "bootstrap" {
+				continue
+			}
+			if !allPlaced(depends[i], placed) {
+				continue
+			}
+			ordered = append(ordered, item)
+			placed[keyOf(item)] = true
+			done[i] = true
+			progress = true
+		}
+	}
+
+	for i, item := range items {
+		if !done[i] && item.Collection != "bootstrap" {
+			ordered = append(ordered, item)
+		}
+	}
+	for _, item := range items {
+		if item.Collection == "bootstrap" {
+			ordered = append(ordered, item)
+		}
+	}
+	return ordered
+}
+
+func allPlaced(depends []references.Endpoint, placed map[references.Endpoint]bool) bool {
+	for _, key := range depends {
+		if !placed[key] {
+			return false
+		}
+	}
+	return true
+}
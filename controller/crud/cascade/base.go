@@ -0,0 +1,72 @@
+package cascade
+
+import (
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/xds"
+	"github.com/CloudNativeWorks/elchi-backend/controller/poker"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+)
+
+type AppHandler struct {
+	Context *db.AppContext
+	XDS     *xds.AppHandler
+	Logger  *logger.Logger
+}
+
+func NewCascadeHandler(context *db.AppContext, xdsHandler *xds.AppHandler) *AppHandler {
+	return &AppHandler{
+		Context: context,
+		XDS:     xdsHandler,
+		Logger:  logger.NewLogger("controller/cascade"),
+	}
+}
+
+// Reasons a resource is part of a deletion plan.
+const (
+	ReasonRoot      = "root"
+	ReasonDependent = "dependent"
+	ReasonOrphan    = "orphan"
+)
+
+// Options of a cascading delete. With IncludeOrphans the resources only used by the
+// deleted ones are deleted as well. Confirm is the fingerprint of the reviewed plan,
+// the delete runs only while the plan is unchanged.
+type Options struct {
+	IncludeOrphans bool   `json:"include_orphans"`
+	Confirm        string `json:"confirm"`
+}
+
+// Item is a resource the plan deletes, Dependents are the deleted resources pointing to it.
+type Item struct {
+	references.Endpoint
+	Reason     string                `json:"reason"`
+	Dependents []references.Endpoint `json:"dependents,omitempty"`
+}
+
+// Kept is a resource used by deleted resources which stays, UsedBy are the resources
+// outside of the plan still pointing to it.
+type Kept struct {
+	references.Endpoint
+	UsedBy []references.Endpoint `json:"used_by,omitempty"`
+}
+
+// Plan lists the resources a cascading delete removes in the order they are deleted,
+// every resource before the ones it points to. Shared resources are used by deleted
+// resources and by others as well, orphaned resources are left without any user.
+// A plan with blocked entries cannot be run.
+type Plan struct {
+	Root        references.Endpoint   `json:"root"`
+	Project     string                `json:"project"`
+	Version     string                `json:"version"`
+	Delete      []Item                `json:"delete"`
+	Shared      []Kept                `json:"shared"`
+	Orphaned    []references.Endpoint `json:"orphaned"`
+	Blocked     []string              `json:"blocked,omitempty"`
+	Fingerprint string                `json:"fingerprint"`
+}
+
+type Result struct {
+	Plan      *Plan            `json:"plan"`
+	Published *poker.Processed `json:"published,omitempty"`
+}
@@ -0,0 +1,563 @@
+package cascade
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/controller/poker"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/drafts"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/services"
+)
+
+// graph is the deletion set of a plan while it is being built.
+type graph struct {
+	items      map[string]*Item
+	referrers  map[string][]references.Endpoint
+	targets    map[string][]references.Endpoint
+	discovered []string
+}
+
+func key(endpoint references.Endpoint) string {
+	return endpoint.Collection + "/" + endpoint.Name
+}
+
+// Plan returns what deleting the resource together with everything pointing to it
+// would remove, without changing anything.
+func (ch *AppHandler) Plan(ctx context.Context, requestDetails models.RequestDetails, opts Options) (*Plan, error) {
+	if err := ch.check(ctx, requestDetails); err != nil {
+		return nil, err
+	}
+
+	root, err := ch.root(ctx, requestDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &graph{
+		items:     map[string]*Item{},
+		referrers: map[string][]references.Endpoint{},
+		targets:   map[string][]references.Endpoint{},
+	}
+	g.add(root, ReasonRoot)
+	if err := ch.closure(ctx, requestDetails, g); err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeOrphans {
+		if err := ch.orphans(ctx, requestDetails, g); err != nil {
+			return nil, err
+		}
+	}
+
+	plan := &Plan{
+		Root:     root,
+		Project:  requestDetails.Project,
+		Version:  requestDetails.Version,
+		Delete:   g.order(),
+		Shared:   []Kept{},
+		Orphaned: []references.Endpoint{},
+	}
+
+	if err := ch.classify(ctx, requestDetails, g, plan); err != nil {
+		return nil, err
+	}
+	if err := ch.block(ctx, requestDetails, plan); err != nil {
+		return nil, err
+	}
+	plan.Fingerprint = fingerprint(plan, opts.IncludeOrphans)
+	return plan, nil
+}
+
+// Delete runs a reviewed plan. The plan is built again and must match the confirmed
+// fingerprint, then its resources are deleted in plan order in one transaction and
+// the nodes of the deleted listeners are poked.
+func (ch *AppHandler) Delete(ctx context.Context, requestDetails models.RequestDetails, opts Options) (*Result, error) {
+	if err := crud.CheckPermanentDelete(requestDetails); err != nil {
+		return nil, err
+	}
+	if opts.Confirm == "" {
+		return nil, errors.New("confirm is required, review the plan and send its fingerprint")
+	}
+
+	plan, err := ch.Plan(ctx, requestDetails, opts)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Fingerprint != opts.Confirm {
+		return nil, errors.New("the plan changed, review it again")
+	}
+	if len(plan.Blocked) > 0 {
+		return nil, errors.New("the plan cannot be run: " + strings.Join(plan.Blocked, ", "))
+	}
+
+	nodes := ch.listenerNodes(ctx, requestDetails, plan)
+
+	session, err := ch.Context.Client.Client().StartSession()
+	if err != nil {
+		return nil, fmt.Errorf("could not start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	var deleted []*models.DBResource
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		deleted = deleted[:0]
+		for _, item := range plan.Delete {
+			removed, err := ch.deleteItem(sc, requestDetails, item)
+			if err != nil {
+				return nil, fmt.Errorf("%s/%s: %w", item.Collection, item.Name, err)
+			}
+			deleted = append(deleted, removed...)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resource := range deleted {
+		crud.RecordRevision(ctx, ch.Context, revisions.OperationDelete, resource, requestDetails.User)
+
+		general := resource.GetGeneral()
+		ch.Context.Invalidation.Publish(invalidation.Event{
+			Collection: general.GType.CollectionString(),
+			Name:       general.Name,
+			Project:    general.Project,
+			Version:    general.Version,
+		})
+	}
+
+	processed := poker.Processed{Listeners: []string{}, Depends: []string{}, Nodes: []poker.NodeChanges{}}
+	for _, item := range plan.Delete {
+		if item.Collection != "listeners" {
+			continue
+		}
+		for _, address := range nodes[item.Name] {
+			poker.HandlePoke(ctx, ch.Context, item.Name, requestDetails.Project, requestDetails.Version, &processed, ch.XDS.PokeService, address)
+		}
+	}
+
+	return &Result{Plan: plan, Published: &processed}, nil
+}
+
+func (ch *AppHandler) check(ctx context.Context, requestDetails models.RequestDetails) error {
+	switch {
+	case requestDetails.Project == "" || requestDetails.Collection == "" || requestDetails.Name == "":
+		return errors.New("project, collection and name are required")
+	case requestDetails.Version == "":
+		return errors.New("version is required")
+	case requestDetails.Collection == "bootstrap":
+		return errors.New("a bootstrap is deleted together with its listener")
+	case !helper.Contains(models.XDSCollections(), requestDetails.Collection):
+		return fmt.Errorf("unknown collection: %s", requestDetails.Collection)
+	case !references.Ready(ctx, ch.Context.Client):
+		return errors.New("the reference index is still being built, try again later")
+	}
+	return nil
+}
+
+func (ch *AppHandler) root(ctx context.Context, requestDetails models.RequestDetails) (references.Endpoint, error) {
+	var found struct {
+		General models.General `bson:"general"`
+	}
+	filter := common.AddUserFilter(requestDetails, resourceFilter(requestDetails.Name, requestDetails.Project, requestDetails.Version))
+	opts := options.FindOne().SetProjection(bson.M{"general": 1})
+	if err := ch.Context.Client.Collection(requestDetails.Collection).FindOne(ctx, filter, opts).Decode(&found); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return references.Endpoint{}, fmt.Errorf("%s/%s does not exist in version %s", requestDetails.Collection, requestDetails.Name, requestDetails.Version)
+		}
+		return references.Endpoint{}, err
+	}
+	return references.Endpoint{Collection: requestDetails.Collection, Name: requestDetails.Name, GType: found.General.GType}, nil
+}
+
+// closure adds every resource which points to a resource of the plan, directly or
+// through others.
+func (ch *AppHandler) closure(ctx context.Context, requestDetails models.RequestDetails, g *graph) error {
+	for i := 0; i < len(g.discovered); i++ {
+		current := g.items[g.discovered[i]].Endpoint
+		referrers, err := ch.referrers(ctx, requestDetails, g, current)
+		if err != nil {
+			return err
+		}
+		for _, referrer := range referrers {
+			g.add(referrer, ReasonDependent)
+		}
+	}
+	return nil
+}
+
+// orphans adds the resources which are only used by resources of the plan, until
+// no more are found. Default resources and resources the user cannot access stay.
+func (ch *AppHandler) orphans(ctx context.Context, requestDetails models.RequestDetails, g *graph) error {
+	for {
+		candidates, err := ch.outside(ctx, requestDetails, g)
+		if err != nil {
+			return err
+		}
+
+		added := false
+		for _, candidate := range candidates {
+			referrers, err := ch.referrers(ctx, requestDetails, g, candidate)
+			if err != nil {
+				return err
+			}
+			if !g.containsAll(referrers) {
+				continue
+			}
+
+			reason, err := ch.blockReason(ctx, requestDetails, candidate)
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				continue
+			}
+			g.add(candidate, ReasonOrphan)
+			added = true
+		}
+		if !added {
+			return nil
+		}
+	}
+}
+
+// classify sorts the resources used by the plan which stay into shared and orphaned ones.
+func (ch *AppHandler) classify(ctx context.Context, requestDetails models.RequestDetails, g *graph, plan *Plan) error {
+	kept, err := ch.outside(ctx, requestDetails, g)
+	if err != nil {
+		return err
+	}
+
+	for _, endpoint := range kept {
+		referrers, err := ch.referrers(ctx, requestDetails, g, endpoint)
+		if err != nil {
+			return err
+		}
+
+		var usedBy []references.Endpoint
+		for _, referrer := range referrers {
+			if _, ok := g.items[key(referrer)]; !ok {
+				usedBy = append(usedBy, referrer)
+			}
+		}
+		if len(usedBy) == 0 {
+			plan.Orphaned = append(plan.Orphaned, endpoint)
+		} else {
+			plan.Shared = append(plan.Shared, Kept{Endpoint: endpoint, UsedBy: usedBy})
+		}
+	}
+	return nil
+}
+
+// block lists the resources of the plan which cannot be deleted.
+func (ch *AppHandler) block(ctx context.Context, requestDetails models.RequestDetails, plan *Plan) error {
+	for _, item := range plan.Delete {
+		reason, err := ch.blockReason(ctx, requestDetails, item.Endpoint)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			plan.Blocked = append(plan.Blocked, fmt.Sprintf("%s/%s %s", item.Collection, item.Name, reason))
+		}
+	}
+	return nil
+}
+
+func (ch *AppHandler) blockReason(ctx context.Context, requestDetails models.RequestDetails, endpoint references.Endpoint) (string, error) {
+	isDefault, err := common.IsDefaultResource(ctx, ch.Context, endpoint.Name, endpoint.Collection, requestDetails.Project)
+	if err != nil {
+		return "", err
+	}
+	if isDefault {
+		return "is a default resource", nil
+	}
+
+	details := requestDetails
+	details.Collection = endpoint.Collection
+	filter := common.AddUserFilter(details, resourceFilter(endpoint.Name, requestDetails.Project, requestDetails.Version))
+	count, err := ch.Context.Client.Collection(endpoint.Collection).CountDocuments(ctx, filter)
+	if err != nil {
+		return "", err
+	}
+	if count == 0 {
+		return "is not accessible", nil
+	}
+	return "", nil
+}
+
+// referrers returns the resources pointing to endpoint, a bootstrap stands for its listener.
+func (ch *AppHandler) referrers(ctx context.Context, requestDetails models.RequestDetails, g *graph, endpoint references.Endpoint) ([]references.Endpoint, error) {
+	k := key(endpoint)
+	if cached, ok := g.referrers[k]; ok {
+		return cached, nil
+	}
+
+	found, err := references.Referrers(ctx, ch.Context.Client, requestDetails.Project, requestDetails.Version, endpoint.Collection, endpoint.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []references.Endpoint
+	seen := map[string]bool{k: true}
+	for _, referrer := range found {
+		if referrer.Collection == "bootstrap" {
+			referrer = references.Endpoint{Collection: "listeners", Name: referrer.Name, GType: models.Listener}
+		}
+		if seen[key(referrer)] {
+			continue
+		}
+		seen[key(referrer)] = true
+		result = append(result, referrer)
+	}
+	g.referrers[k] = result
+	return result, nil
+}
+
+// outside returns the resources outside of the plan which resources of the plan
+// point to, the bootstrap of a listener included.
+func (ch *AppHandler) outside(ctx context.Context, requestDetails models.RequestDetails, g *graph) ([]references.Endpoint, error) {
+	var result []references.Endpoint
+	seen := map[string]bool{}
+	for _, k := range g.discovered {
+		targets, err := ch.targets(ctx, requestDetails, g, g.items[k].Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range targets {
+			tk := key(target)
+			if _, ok := g.items[tk]; ok || seen[tk] {
+				continue
+			}
+			seen[tk] = true
+			result = append(result, target)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return key(result[i]) < key(result[j]) })
+	return result, nil
+}
+
+func (ch *AppHandler) targets(ctx context.Context, requestDetails models.RequestDetails, g *graph, endpoint references.Endpoint) ([]references.Endpoint, error) {
+	k := key(endpoint)
+	if cached, ok := g.targets[k]; ok {
+		return cached, nil
+	}
+
+	sources := []references.Endpoint{endpoint}
+	if endpoint.Collection == "listeners" {
+		sources = append(sources, references.Endpoint{Collection: "bootstrap", Name: endpoint.Name})
+	}
+
+	var result []references.Endpoint
+	for _, source := range sources {
+		found, err := references.Targets(ctx, ch.Context.Client, requestDetails.Project, requestDetails.Version, source)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, found...)
+	}
+	g.targets[k] = result
+	return result, nil
+}
+
+// deleteItem moves one resource of the plan to the trash and deletes it, a listener
+// with its bootstrap, service and admin port. It returns the deleted resources.
+func (ch *AppHandler) deleteItem(ctx context.Context, requestDetails models.RequestDetails, item Item) ([]*models.DBResource, error) {
+	itemDetails := requestDetails
+	itemDetails.Collection = item.Collection
+	itemDetails.Name = item.Name
+	itemDetails.GType = item.GType
+
+	filter := resourceFilter(item.Name, requestDetails.Project, requestDetails.Version)
+	resource, err := revisions.LoadResource(ctx, ch.Context.Client, item.Collection, filter)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("was deleted meanwhile, review the plan again")
+		}
+		return nil, err
+	}
+
+	if err := crud.MoveToTrash(ctx, ch.Context, itemDetails, item.Collection, filter); err != nil {
+		return nil, err
+	}
+	if err := ch.remove(ctx, item.Collection, item.Name, requestDetails.Project, requestDetails.Version, filter); err != nil {
+		return nil, err
+	}
+
+	deleted := []*models.DBResource{resource}
+	if item.Collection != "listeners" {
+		return deleted, nil
+	}
+
+	for _, record := range crud.ListenerRecords(item.Name, requestDetails.Project) {
+		if record.Collection != "bootstrap" {
+			if _, err := ch.Context.Client.Collection(record.Collection).DeleteOne(ctx, record.Filter); err != nil {
+				return nil, fmt.Errorf("could not delete %s of listener: %w", record.Collection, err)
+			}
+			continue
+		}
+
+		bootstrap, err := revisions.LoadResource(ctx, ch.Context.Client, "bootstrap", record.Filter)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				continue
+			}
+			return nil, err
+		}
+		if err := ch.remove(ctx, "bootstrap", item.Name, requestDetails.Project, "", record.Filter); err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, bootstrap)
+	}
+	return deleted, nil
+}
+
+// remove deletes the document matched by filter with its references and drafts.
+func (ch *AppHandler) remove(ctx context.Context, collection, name, project, version string, filter bson.M) error {
+	result, err := ch.Context.Client.Collection(collection).DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("could not delete %s/%s: %w", collection, name, err)
+	}
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("%s/%s was deleted meanwhile, review the plan again", collection, name)
+	}
+
+	if err := resources.RemoveReferences(ctx, ch.Context, collection, name, project, version); err != nil {
+		return fmt.Errorf("could not remove references of %s/%s: %w", collection, name, err)
+	}
+	return drafts.RemoveFor(ctx, ch.Context.Client, collection, name, project, version)
+}
+
+// listenerNodes returns the downstream addresses to poke for every listener of the
+// plan, read before their services are deleted. An unmanaged listener has one node
+// without an address.
+func (ch *AppHandler) listenerNodes(ctx context.Context, requestDetails models.RequestDetails, plan *Plan) map[string][]string {
+	nodes := map[string][]string{}
+	for _, item := range plan.Delete {
+		if item.Collection != "listeners" {
+			continue
+		}
+
+		var found struct {
+			General models.General `bson:"general"`
+		}
+		filter := resourceFilter(item.Name, requestDetails.Project, requestDetails.Version)
+		if err := ch.Context.Client.Collection("listeners").FindOne(ctx, filter).Decode(&found); err != nil {
+			ch.Logger.Errorf("Could not read listener %s: %v", item.Name, err)
+			continue
+		}
+
+		if !found.General.Managed {
+			nodes[item.Name] = []string{""}
+			continue
+		}
+		for _, client := range services.FetchDownstreamAddressFromService(ch.Context.Client, item.Name, requestDetails.Project, requestDetails.Version) {
+			nodes[item.Name] = append(nodes[item.Name], client.DownstreamAddress)
+		}
+	}
+	return nodes
+}
+
+func (g *graph) add(endpoint references.Endpoint, reason string) {
+	k := key(endpoint)
+	if _, ok := g.items[k]; ok {
+		return
+	}
+	g.items[k] = &Item{Endpoint: endpoint, Reason: reason}
+	g.discovered = append(g.discovered, k)
+}
+
+func (g *graph) containsAll(endpoints []references.Endpoint) bool {
+	for _, endpoint := range endpoints {
+		if _, ok := g.items[key(endpoint)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// order returns the items so that every resource comes before the resources it
+// points to. Resources on a reference cycle are appended by name.
+func (g *graph) order() []Item {
+	inDegree := map[string]int{}
+	children := map[string][]string{}
+	for k := range g.items {
+		for _, referrer := range g.referrers[k] {
+			rk := key(referrer)
+			if _, ok := g.items[rk]; !ok {
+				continue
+			}
+			g.items[k].Dependents = append(g.items[k].Dependents, referrer)
+			children[rk] = append(children[rk], k)
+			inDegree[k]++
+		}
+	}
+
+	var ready []string
+	for k := range g.items {
+		if inDegree[k] == 0 {
+			ready = append(ready, k)
+		}
+	}
+	sort.Strings(ready)
+
+	ordered := make([]Item, 0, len(g.items))
+	done := map[string]bool{}
+	for len(ready) > 0 {
+		current := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, *g.items[current])
+		done[current] = true
+
+		for _, child := range children[current] {
+			inDegree[child]--
+			if inDegree[child] == 0 {
+				ready = append(ready, child)
+			}
+		}
+		sort.Strings(ready)
+	}
+
+	var rest []string
+	for k := range g.items {
+		if !done[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	for _, k := range rest {
+		ordered = append(ordered, *g.items[k])
+	}
+	return ordered
+}
+
+func fingerprint(plan *Plan, includeOrphans bool) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n%s\n", plan.Project, plan.Version, strconv.FormatBool(includeOrphans))
+	for _, item := range plan.Delete {
+		fmt.Fprintf(hash, "%s\n%s\n", key(item.Endpoint), item.Reason)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func resourceFilter(name, project, version string) bson.M {
+	return bson.M{"general.name": name, "general.project": project, "general.version": version}
+}
@@ -0,0 +1,86 @@
+package changeset
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/extension"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/xds"
+	"github.com/CloudNativeWorks/elchi-backend/controller/poker"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const collectionName = "changesets"
+
+const (
+	StatusOpen      = "open"
+	StatusCommitted = "committed"
+	StatusDiscarded = "discarded"
+)
+
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+type AppHandler struct {
+	Context   *db.AppContext
+	XDS       *xds.AppHandler
+	Extension *extension.AppHandler
+	Logger    *logger.Logger
+}
+
+func NewChangeSetHandler(context *db.AppContext, xdsHandler *xds.AppHandler, extensionHandler *extension.AppHandler) *AppHandler {
+	return &AppHandler{
+		Context:   context,
+		XDS:       xdsHandler,
+		Extension: extensionHandler,
+		Logger:    logger.NewLogger("controller/changeset"),
+	}
+}
+
+type ChangeSet struct {
+	ID          primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	Project     string              `json:"project" bson:"project"`
+	Description string              `json:"description" bson:"description"`
+	Status      string              `json:"status" bson:"status"`
+	Operations  []Operation         `json:"operations" bson:"operations"`
+	CreatedBy   string              `json:"created_by" bson:"created_by"`
+	CreatedAt   primitive.DateTime  `json:"created_at" bson:"created_at"`
+	UpdatedAt   primitive.DateTime  `json:"updated_at" bson:"updated_at"`
+	CommittedAt *primitive.DateTime `json:"committed_at,omitempty" bson:"committed_at,omitempty"`
+	LastError   string              `json:"last_error,omitempty" bson:"last_error,omitempty"`
+}
+
+// Operation is one pending write. Collection, name and version default to the
+// general section of the resource for creates and updates.
+type Operation struct {
+	Op              string             `json:"op" bson:"op"`
+	Collection      string             `json:"collection" bson:"collection"`
+	Name            string             `json:"name" bson:"name"`
+	Version         string             `json:"version" bson:"version"`
+	GType           models.GTypes      `json:"gtype" bson:"gtype"`
+	ExpectedVersion string             `json:"expected_version,omitempty" bson:"expected_version,omitempty"`
+	Resource        *models.DBResource `json:"resource,omitempty" bson:"resource,omitempty"`
+}
+
+// OperationResult is the outcome of one operation. Operations after a failed one are
+// not run and are marked NotChecked.
+type OperationResult struct {
+	Index      int    `json:"index"`
+	Op         string `json:"op"`
+	Collection string `json:"collection"`
+	Name       string `json:"name"`
+	Error      string `json:"error,omitempty"`
+	NotChecked bool   `json:"not_checked,omitempty"`
+	Response   any    `json:"response,omitempty"`
+}
+
+type CommitResult struct {
+	ChangeSet *ChangeSet        `json:"changeset"`
+	Valid     bool              `json:"valid"`
+	Results   []OperationResult `json:"results"`
+	Published *poker.Processed  `json:"published,omitempty"`
+}
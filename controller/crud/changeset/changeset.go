@@ -0,0 +1,203 @@
+package changeset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+var (
+	errChangeSetNotFound = errors.New("change set not found")
+	errChangeSetClosed   = errors.New("change set is not open")
+)
+
+// changeSets decodes nested documents as maps so resource bodies marshal to plain json.
+func (ch *AppHandler) changeSets() *mongo.Collection {
+	opts := options.Collection().SetBSONOptions(&options.BSONOptions{DefaultDocumentM: true})
+	return ch.Context.Client.Collection(collectionName, opts)
+}
+
+func (ch *AppHandler) OpenChangeSet(ctx context.Context, requestDetails models.RequestDetails, description string) (any, error) {
+	if requestDetails.Project == "" {
+		return nil, errors.New("project is required")
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	changeSet := &ChangeSet{
+		Project:     requestDetails.Project,
+		Description: description,
+		Status:      StatusOpen,
+		Operations:  []Operation{},
+		CreatedBy:   requestDetails.User.UserName,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	result, err := ch.changeSets().InsertOne(ctx, changeSet)
+	if err != nil {
+		return nil, fmt.Errorf("could not open change set: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		changeSet.ID = oid
+	}
+	return changeSet, nil
+}
+
+func (ch *AppHandler) ListChangeSets(ctx context.Context, requestDetails models.RequestDetails, status string) (any, error) {
+	filter := bson.M{"project": requestDetails.Project}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetProjection(bson.M{"operations.resource": 0})
+	cursor, err := ch.changeSets().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not list change sets: %w", err)
+	}
+
+	changeSets := []ChangeSet{}
+	if err := cursor.All(ctx, &changeSets); err != nil {
+		return nil, fmt.Errorf("could not decode change sets: %w", err)
+	}
+	return changeSets, nil
+}
+
+func (ch *AppHandler) GetChangeSet(ctx context.Context, requestDetails models.RequestDetails, id string) (any, error) {
+	return ch.getChangeSet(ctx, id, requestDetails.Project)
+}
+
+func (ch *AppHandler) getChangeSet(ctx context.Context, id, project string) (*ChangeSet, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid change set id")
+	}
+
+	var changeSet ChangeSet
+	err = ch.changeSets().FindOne(ctx, bson.M{"_id": objectID, "project": project}).Decode(&changeSet)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errChangeSetNotFound
+		}
+		return nil, err
+	}
+	return &changeSet, nil
+}
+
+// AddOperation appends an operation to an open change set.
+func (ch *AppHandler) AddOperation(ctx context.Context, requestDetails models.RequestDetails, id string, operation Operation) (any, error) {
+	if err := normalizeOperation(&operation, requestDetails.Project); err != nil {
+		return nil, err
+	}
+
+	changeSet, err := ch.getChangeSet(ctx, id, requestDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range changeSet.Operations {
+		if existing.Collection == operation.Collection && existing.Name == operation.Name && existing.Version == operation.Version {
+			return nil, fmt.Errorf("change set already has an operation on %s/%s", operation.Collection, operation.Name)
+		}
+	}
+
+	update := bson.M{
+		"$push": bson.M{"operations": operation},
+		"$set":  bson.M{"updated_at": primitive.NewDateTimeFromTime(time.Now())},
+	}
+	return ch.updateOpen(ctx, changeSet, update)
+}
+
+// RemoveOperation drops the operation at the given index from an open change set.
+func (ch *AppHandler) RemoveOperation(ctx context.Context, requestDetails models.RequestDetails, id string, index int) (any, error) {
+	changeSet, err := ch.getChangeSet(ctx, id, requestDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 || index >= len(changeSet.Operations) {
+		return nil, fmt.Errorf("operation %d does not exist", index)
+	}
+
+	operations := append(changeSet.Operations[:index:index], changeSet.Operations[index+1:]...)
+	update := bson.M{"$set": bson.M{
+		"operations": operations,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}}
+	return ch.updateOpen(ctx, changeSet, update)
+}
+
+func (ch *AppHandler) DiscardChangeSet(ctx context.Context, requestDetails models.RequestDetails, id string) (any, error) {
+	changeSet, err := ch.getChangeSet(ctx, id, requestDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"status":     StatusDiscarded,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}}
+	return ch.updateOpen(ctx, changeSet, update)
+}
+
+// updateOpen applies the update only while the change set is still open.
+func (ch *AppHandler) updateOpen(ctx context.Context, changeSet *ChangeSet, update bson.M) (*ChangeSet, error) {
+	filter := bson.M{"_id": changeSet.ID, "status": StatusOpen}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated ChangeSet
+	if err := ch.changeSets().FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errChangeSetClosed
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func normalizeOperation(operation *Operation, project string) error {
+	switch operation.Op {
+	case OpCreate, OpUpdate:
+		if operation.Resource == nil {
+			return fmt.Errorf("%s operation requires a resource", operation.Op)
+		}
+		general := operation.Resource.General
+		if general.Project != "" && general.Project != project {
+			return errors.New("resource belongs to another project")
+		}
+		operation.Resource.General.Project = project
+		if operation.Collection == "" {
+			operation.Collection = general.Collection
+		}
+		if operation.Name == "" {
+			operation.Name = general.Name
+		}
+		if operation.Version == "" {
+			operation.Version = general.Version
+		}
+		if operation.GType == "" {
+			operation.GType = general.GType
+		}
+	case OpDelete:
+		operation.Resource = nil
+	default:
+		return fmt.Errorf("unknown operation: %s", operation.Op)
+	}
+
+	if operation.Collection == "" && operation.GType != "" {
+		operation.Collection = operation.GType.CollectionString()
+	}
+
+	if operation.Collection == "" || operation.Name == "" || operation.Version == "" || operation.GType == "" {
+		return errors.New("operation requires collection, name, version and gtype")
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package changeset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+// errDryRun aborts the transaction after a successful validation run.
+var errDryRun = errors.New("change set dry run")
+
+var phaseOrder = []string{OpCreate, OpUpdate, OpDelete}
+
+// ValidateChangeSet runs the operations inside a transaction which is always aborted,
+// so the operations are checked against each other without writing anything. Like a
+// commit it stops at the first failing operation, the later ones are not checked.
+func (ch *AppHandler) ValidateChangeSet(ctx context.Context, requestDetails models.RequestDetails, id string) (any, error) {
+	changeSet, err := ch.getChangeSet(ctx, id, requestDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	if changeSet.Status != StatusOpen {
+		return nil, errChangeSetClosed
+	}
+
+	results, err := ch.apply(ctx, changeSet, requestDetails, true)
+	if err != nil && !errors.Is(err, errDryRun) {
+		return CommitResult{ChangeSet: changeSet, Valid: false, Results: results}, nil
+	}
+
+	return CommitResult{ChangeSet: changeSet, Valid: true, Results: results}, nil
+}
+
+// CommitChangeSet applies all operations in one transaction and publishes the
+// affected resources once. A failed commit leaves the resources and the change set untouched.
+func (ch *AppHandler) CommitChangeSet(ctx context.Context, requestDetails models.RequestDetails, id string) (any, error) {
+	changeSet, err := ch.getChangeSet(ctx, id, requestDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	if changeSet.Status != StatusOpen {
+		return nil, errChangeSetClosed
+	}
+
+	if len(changeSet.Operations) == 0 {
+		return nil, errors.New("change set has no operations")
+	}
+
+	results, err := ch.apply(ctx, changeSet, requestDetails, false)
+	if err != nil {
+		now := primitive.NewDateTimeFromTime(time.Now())
+		update := bson.M{"$set": bson.M{"last_error": err.Error(), "updated_at": now}}
+		if _, updateErr := ch.updateOpen(ctx, changeSet, update); updateErr != nil {
+			ch.Logger.Errorf("Could not record change set error: %v", updateErr)
+		}
+		return CommitResult{ChangeSet: changeSet, Valid: false, Results: results}, fmt.Errorf("change set was not committed: %w", err)
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	update := bson.M{
+		"$set":   bson.M{"status": StatusCommitted, "committed_at": now, "updated_at": now},
+		"$unset": bson.M{"last_error": ""},
+	}
+	committed, err := ch.updateOpen(ctx, changeSet, update)
+	if err != nil {
+		ch.Logger.Errorf("Could not mark change set %s committed: %v", id, err)
+		committed = changeSet
+	}
+
+	touched := ch.touchedResources(changeSet)
+	for _, resource := range touched {
+		general := resource.GetGeneral()
+		ch.Context.Invalidation.Publish(invalidation.Event{
+			Collection: general.Collection,
+			Name:       general.Name,
+			Project:    general.Project,
+			Version:    general.Version,
+		})
+	}
+
+	result := CommitResult{ChangeSet: committed, Valid: true, Results: results}
+	saveOrPublish := requestDetails.SaveOrPublish
+	if saveOrPublish == "" {
+		saveOrPublish = models.SavePublish
+	}
+	if saveOrPublish == models.SavePublish {
+		result.Published = crud.PublishChanges(ctx, ch.Context, touched, ch.XDS.PokeService)
+	}
+
+	return result, nil
+}
+
+// apply runs the operations in a single transaction, creates first, then updates,
+// then deletes, keeping the submitted order inside each phase. It stops at the first
+// failure, an aborted transaction cannot check the operations after it.
+func (ch *AppHandler) apply(ctx context.Context, changeSet *ChangeSet, requestDetails models.RequestDetails, dryRun bool) ([]OperationResult, error) {
+	session, err := ch.Context.Client.Client().StartSession()
+	if err != nil {
+		return nil, fmt.Errorf("could not start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	if dryRun {
+		ctx = crud.WithDryRun(ctx)
+	}
+
+	var ordered []int
+	for _, phase := range phaseOrder {
+		for index, operation := range changeSet.Operations {
+			if operation.Op == phase {
+				ordered = append(ordered, index)
+			}
+		}
+	}
+
+	var results []OperationResult
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		results = make([]OperationResult, 0, len(changeSet.Operations))
+		for i, index := range ordered {
+			operation := changeSet.Operations[index]
+			result := OperationResult{Index: index, Op: operation.Op, Collection: operation.Collection, Name: operation.Name}
+			response, err := ch.applyOperation(sc, operation, requestDetails)
+			if err != nil {
+				result.Error = err.Error()
+				results = append(results, result)
+				for _, rest := range ordered[i+1:] {
+					skipped := changeSet.Operations[rest]
+					results = append(results, OperationResult{Index: rest, Op: skipped.Op, Collection: skipped.Collection, Name: skipped.Name, NotChecked: true})
+				}
+				return nil, fmt.Errorf("operation %d (%s %s/%s): %w", index, operation.Op, operation.Collection, operation.Name, err)
+			}
+			result.Response = response
+			results = append(results, result)
+		}
+
+		if dryRun {
+			return nil, errDryRun
+		}
+		return nil, nil
+	})
+
+	return results, err
+}
+
+func (ch *AppHandler) applyOperation(ctx context.Context, operation Operation, requestDetails models.RequestDetails) (any, error) {
+	details := requestDetails
+	details.Name = operation.Name
+	details.Collection = operation.Collection
+	details.Version = operation.Version
+	details.GType = operation.GType
+	details.IfMatch = operation.ExpectedVersion
+	details.SaveOrPublish = models.SaveLive
+
+	isExtension := helper.Contains([]string{"filters", "extensions"}, operation.Collection)
+
+	var resource *models.DBResource
+	if operation.Resource != nil {
+		var err error
+		if resource, err = plainResource(operation.Resource); err != nil {
+			return nil, err
+		}
+		details.CanonicalName = resource.General.CanonicalName
+	}
+
+	switch operation.Op {
+	case OpCreate:
+		resource.Resource.Version = "1"
+		if isExtension {
+			return ch.Extension.SetExtension(ctx, resource, details)
+		}
+		return ch.XDS.SetResource(ctx, resource, details)
+	case OpUpdate:
+		resourceID, err := ch.resolveID(ctx, operation, requestDetails.Project)
+		if err != nil {
+			return nil, err
+		}
+		resource.ID = resourceID
+		details.ResourceID = resourceID.Hex()
+		if isExtension {
+			return ch.Extension.UpdateExtensions(ctx, resource, details)
+		}
+		return ch.XDS.UpdateResource(ctx, resource, details)
+	case OpDelete:
+		resourceID, err := ch.resolveID(ctx, operation, requestDetails.Project)
+		if err != nil {
+			return nil, err
+		}
+		details.ResourceID = resourceID.Hex()
+		if isExtension {
+			return ch.Extension.DelExtension(ctx, nil, details)
+		}
+		return ch.XDS.DelResource(ctx, nil, details)
+	}
+
+	return nil, fmt.Errorf("unknown operation: %s", operation.Op)
+}
+
+func (ch *AppHandler) resolveID(ctx context.Context, operation Operation, project string) (primitive.ObjectID, error) {
+	filter := bson.M{
+		"general.name":    operation.Name,
+		"general.project": project,
+		"general.version": operation.Version,
+	}
+
+	var found struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := ch.Context.Client.Collection(operation.Collection).FindOne(ctx, filter).Decode(&found); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return primitive.NilObjectID, fmt.Errorf("%s/%s does not exist", operation.Collection, operation.Name)
+		}
+		return primitive.NilObjectID, err
+	}
+	return found.ID, nil
+}
+
+// touchedResources lists the created and updated resources, deleted ones have
+// nothing left to publish.
+func (ch *AppHandler) touchedResources(changeSet *ChangeSet) []models.ResourceClass {
+	touched := []models.ResourceClass{}
+	for _, operation := range changeSet.Operations {
+		if operation.Op == OpDelete || operation.Resource == nil {
+			continue
+		}
+		resource, err := plainResource(operation.Resource)
+		if err != nil {
+			ch.Logger.Errorf("Could not decode change set resource %s: %v", operation.Name, err)
+			continue
+		}
+		touched = append(touched, resource)
+	}
+	return touched
+}
+
+// plainResource round trips through json so the body holds the same types as a request body.
+func plainResource(resource *models.DBResource) (*models.DBResource, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var plain models.DBResource
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return nil, err
+	}
+	return &plain, nil
+}
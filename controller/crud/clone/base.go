@@ -0,0 +1,49 @@
+package clone
+
+import (
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/bundle"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+)
+
+type AppHandler struct {
+	Context *db.AppContext
+	Bundle  *bundle.AppHandler
+	Logger  *logger.Logger
+}
+
+func NewCloneHandler(context *db.AppContext, bundleHandler *bundle.AppHandler) *AppHandler {
+	return &AppHandler{
+		Context: context,
+		Bundle:  bundleHandler,
+		Logger:  logger.NewLogger("controller/clone"),
+	}
+}
+
+// Options decide how the copies are named and which resources stay shared. Mapping
+// keys are either "collection/name" or a bare name, a mapped name wins over prefix
+// and suffix. Share entries are "collection/name" or a whole collection.
+type Options struct {
+	Prefix        string            `json:"prefix"`
+	Suffix        string            `json:"suffix"`
+	Mapping       map[string]string `json:"mapping"`
+	Share         []string          `json:"share"`
+	ShareDefaults bool              `json:"share_defaults"`
+	SaveOrPublish string            `json:"-"`
+}
+
+type Copy struct {
+	Collection string `json:"collection"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+type Result struct {
+	Root     references.Endpoint   `json:"root"`
+	Copies   []Copy                `json:"copies"`
+	Shared   []references.Endpoint `json:"shared"`
+	Missing  []references.Endpoint `json:"missing,omitempty"`
+	Warnings []string              `json:"warnings,omitempty"`
+	Import   *bundle.ImportResult  `json:"import,omitempty"`
+}
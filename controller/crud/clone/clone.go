@@ -0,0 +1,232 @@
+package clone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/bundle"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
+)
+
+type node struct {
+	endpoint references.Endpoint
+	resource *models.DBResource
+}
+
+// Clone copies a resource and everything it depends on under new names and points the
+// references between the copies at each other, shared resources are referenced as they
+// are. The copies are written with a bundle import, so they are created in dependency
+// order in one transaction and a cloned listener gets its own bootstrap and service.
+// With preview set nothing is written.
+func (ch *AppHandler) Clone(ctx context.Context, requestDetails models.RequestDetails, cloneOptions Options, preview bool) (*Result, error) {
+	if err := checkRequest(requestDetails, cloneOptions); err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"general.name": requestDetails.Name, "general.project": requestDetails.Project, "general.version": requestDetails.Version}
+	root, err := revisions.LoadResource(ctx, ch.Context.Client, requestDetails.Collection, common.AddUserFilter(requestDetails, filter))
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s/%s does not exist", requestDetails.Collection, requestDetails.Name)
+		}
+		return nil, err
+	}
+
+	result := &Result{
+		Root:   references.Endpoint{Collection: requestDetails.Collection, Name: requestDetails.Name, GType: root.General.GType},
+		Copies: []Copy{},
+		Shared: []references.Endpoint{},
+	}
+
+	nodes, err := ch.walk(ctx, root, result, requestDetails, cloneOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := newNames(nodes, cloneOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	copies := &bundle.Bundle{
+		APIVersion: bundle.APIVersion,
+		Kind:       bundle.Kind,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Project:    requestDetails.Project,
+		Version:    requestDetails.Version,
+		Resources:  make([]bundle.Resource, 0, len(nodes)),
+	}
+	for _, n := range nodes {
+		item, err := ch.copyResource(n, names)
+		if err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", n.endpoint.Collection, n.endpoint.Name, err)
+		}
+		copies.Resources = append(copies.Resources, item)
+		result.Copies = append(result.Copies, Copy{Collection: n.endpoint.Collection, From: n.endpoint.Name, To: item.General.Name})
+
+		if n.endpoint.Collection == "listeners" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("listeners/%s binds the same addresses as listeners/%s, change them before both are deployed", item.General.Name, n.endpoint.Name))
+		}
+	}
+	for _, missing := range result.Missing {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s/%s does not exist, references to it are copied unchanged", missing.Collection, missing.Name))
+	}
+
+	imported, err := ch.Bundle.Import(ctx, requestDetails, copies, bundle.ImportOptions{
+		OnConflict:    bundle.OnConflictFail,
+		SaveOrPublish: cloneOptions.SaveOrPublish,
+	}, preview)
+	result.Import = imported
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func checkRequest(requestDetails models.RequestDetails, cloneOptions Options) error {
+	switch {
+	case requestDetails.Project == "" || requestDetails.Version == "" || requestDetails.Collection == "" || requestDetails.Name == "":
+		return errors.New("project, version, collection and name are required")
+	case requestDetails.Collection == "bootstrap":
+		return errors.New("a bootstrap is created together with its listener and cannot be cloned")
+	case !helper.Contains(models.XDSCollections(), requestDetails.Collection):
+		return fmt.Errorf("unknown collection: %s", requestDetails.Collection)
+	case cloneOptions.Prefix == "" && cloneOptions.Suffix == "" && len(cloneOptions.Mapping) == 0:
+		return errors.New("a prefix, suffix or name mapping is required")
+	}
+	return nil
+}
+
+// walk follows the upstream references from the root breadth first and returns the
+// resources to copy, the root first. Dependencies the user cannot read stay shared.
+func (ch *AppHandler) walk(ctx context.Context, root *models.DBResource, result *Result, requestDetails models.RequestDetails, cloneOptions Options) ([]node, error) {
+	rootKey := references.Endpoint{Collection: requestDetails.Collection, Name: requestDetails.Name}
+	nodes := []node{{endpoint: rootKey, resource: root}}
+	visited := map[references.Endpoint]bool{rootKey: true}
+
+	for i := 0; i < len(nodes); i++ {
+		for _, endpoint := range resources.ReferencedEndpoints(nodes[i].resource, ch.Logger.Logger) {
+			key := references.Endpoint{Collection: endpoint.Collection, Name: endpoint.Name}
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+
+			shared, err := ch.isShared(ctx, key, requestDetails.Project, cloneOptions)
+			if err != nil {
+				return nil, err
+			}
+			if shared {
+				result.Shared = append(result.Shared, endpoint)
+				continue
+			}
+
+			details := requestDetails
+			details.Collection = endpoint.Collection
+			filter := bson.M{"general.name": endpoint.Name, "general.version": requestDetails.Version}
+			resource, err := revisions.LoadResource(ctx, ch.Context.Client, endpoint.Collection, common.AddUserFilter(details, filter))
+			if err == nil {
+				nodes = append(nodes, node{endpoint: key, resource: resource})
+				continue
+			}
+			if !errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, err
+			}
+
+			// A dependency the user cannot read is referenced as it is, like a shared one.
+			filter = bson.M{"general.name": endpoint.Name, "general.project": requestDetails.Project, "general.version": requestDetails.Version}
+			exists, err := ch.Context.Client.Collection(endpoint.Collection).CountDocuments(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			if exists > 0 {
+				result.Shared = append(result.Shared, endpoint)
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s/%s is not accessible, references to it are kept", endpoint.Collection, endpoint.Name))
+				continue
+			}
+			result.Missing = append(result.Missing, endpoint)
+		}
+	}
+	return nodes, nil
+}
+
+func (ch *AppHandler) isShared(ctx context.Context, endpoint references.Endpoint, project string, cloneOptions Options) (bool, error) {
+	for _, entry := range cloneOptions.Share {
+		if entry == endpoint.Collection || entry == endpoint.Collection+"/"+endpoint.Name {
+			return true, nil
+		}
+	}
+	if !cloneOptions.ShareDefaults {
+		return false, nil
+	}
+	return common.IsDefaultResource(ctx, ch.Context, endpoint.Name, endpoint.Collection, project)
+}
+
+func newNames(nodes []node, cloneOptions Options) (map[references.Endpoint]string, error) {
+	names := make(map[references.Endpoint]string, len(nodes))
+	taken := make(map[references.Endpoint]bool, len(nodes))
+	for _, n := range nodes {
+		name := cloneOptions.newName(n.endpoint)
+		if name == "" || name == n.endpoint.Name {
+			return nil, fmt.Errorf("%s/%s would keep its name, add it to the mapping or set a prefix or suffix", n.endpoint.Collection, n.endpoint.Name)
+		}
+
+		key := references.Endpoint{Collection: n.endpoint.Collection, Name: name}
+		if taken[key] {
+			return nil, fmt.Errorf("more than one resource would be copied to %s/%s", n.endpoint.Collection, name)
+		}
+		taken[key] = true
+		names[n.endpoint] = name
+	}
+	return names, nil
+}
+
+func (o Options) newName(endpoint references.Endpoint) string {
+	if name, ok := o.Mapping[endpoint.Collection+"/"+endpoint.Name]; ok {
+		return name
+	}
+	if name, ok := o.Mapping[endpoint.Name]; ok {
+		return name
+	}
+	return o.Prefix + endpoint.Name + o.Suffix
+}
+
+// copyResource renames the resource and points its references to other copied
+// resources at their copies.
+func (ch *AppHandler) copyResource(n node, names map[references.Endpoint]string) (bundle.Resource, error) {
+	resource := n.resource
+	newName := names[n.endpoint]
+	resource.SetResource(resources.RenameBody(resource.GetResource(), n.endpoint.Name, newName))
+
+	for _, endpoint := range resources.ReferencedEndpoints(resource, ch.Logger.Logger) {
+		target, ok := names[references.Endpoint{Collection: endpoint.Collection, Name: endpoint.Name}]
+		if !ok {
+			continue
+		}
+		if _, err := resources.RewriteReferences(resource, endpoint.Collection, endpoint.Name, target, ch.Logger.Logger); err != nil {
+			return bundle.Resource{}, err
+		}
+	}
+
+	general := resource.GetGeneral()
+	general.Name = newName
+	general.Project = ""
+	general.Collection = n.endpoint.Collection
+	general.Permissions = models.Permissions{}
+	general.TypedConfig = nil
+	general.CreatedAt = 0
+	general.UpdatedAt = 0
+	general.UpdatedBy = ""
+
+	return bundle.Resource{Collection: n.endpoint.Collection, General: general, Resource: resource.GetResource()}, nil
+}
@@ -10,9 +10,22 @@ import (
 	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models/downstreamfilters"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
 )
 
 func IsDeletable(ctx context.Context, appCtx *db.AppContext, gtype models.GTypes, dfm downstreamfilters.DownstreamFilter) []string {
+	if references.Ready(ctx, appCtx.Client) {
+		referrers, err := references.Referrers(ctx, appCtx.Client, dfm.Project, dfm.Version, gtype.CollectionString(), dfm.Name)
+		if err == nil {
+			var deletableNames []string
+			for _, referrer := range referrers {
+				deletableNames = append(deletableNames, fmt.Sprintf("%s - %s", referrer.Name, referrer.GType.PrettyName()))
+			}
+			return deletableNames
+		}
+		appCtx.Logger.Errorf("Error finding referrers, falling back to downstream filters: %v", err)
+	}
+
 	downstreamFilters := gtype.DownstreamFilters(dfm)
 	var deletableNames []string
 
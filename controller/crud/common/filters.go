@@ -6,6 +6,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/CloudNativeWorks/elchi-backend/pkg/labels"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 )
 
@@ -16,12 +17,11 @@ func AddUserFilter(details models.RequestDetails, mainFilter bson.M) bson.M {
 
 	userFilter := bson.M{}
 	if !details.User.IsOwner && details.User.Role != models.RoleAdmin {
-		userFilter = bson.M{
-			"$or": []bson.M{
-				{"general.permissions.groups": bson.M{"$in": details.User.Groups}},
-				{"general.permissions.users": details.User.UserID},
-			},
+		grants := []bson.M{
+			{"general.permissions.groups": bson.M{"$in": details.User.Groups}},
+			{"general.permissions.users": details.User.UserID},
 		}
+		userFilter = bson.M{"$or": append(grants, labelScopeFilters(details)...)}
 	}
 
 	mainFilter["general.project"] = details.Project
@@ -30,6 +30,43 @@ func AddUserFilter(details models.RequestDetails, mainFilter bson.M) bson.M {
 	return mainFilter
 }
 
+// labelScopeFilters returns the selector filters of the label scopes the groups of
+// the user hold on the collection of the request.
+func labelScopeFilters(details models.RequestDetails) []bson.M {
+	var scopes []bson.M
+	for _, scope := range details.User.LabelScopes {
+		if scope.Collection != "" && scope.Collection != details.Collection {
+			continue
+		}
+		selector, err := labels.Parse(scope.Selector)
+		if err != nil || len(selector) == 0 {
+			continue
+		}
+		scopes = append(scopes, selector.Filter(labels.Field))
+	}
+	return scopes
+}
+
+// AddSelectorFilter narrows the filter to the resources matching the label selector
+// of the request.
+func AddSelectorFilter(details models.RequestDetails, mainFilter bson.M) (bson.M, error) {
+	if mainFilter == nil {
+		mainFilter = bson.M{}
+	}
+
+	selector, err := labels.Parse(details.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(selector) == 0 {
+		return mainFilter, nil
+	}
+
+	and, _ := mainFilter["$and"].(bson.A)
+	mainFilter["$and"] = append(and, selector.Filter(labels.Field))
+	return mainFilter, nil
+}
+
 func AddResourceIDFilter(requestDetails models.RequestDetails, mainFilter bson.M) (bson.M, error) {
 	if mainFilter == nil {
 		mainFilter = bson.M{}
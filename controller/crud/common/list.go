@@ -0,0 +1,45 @@
+package common
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/pagination"
+)
+
+// GeneralListSpec is the list spec of collections whose documents have a general
+// section, projection is used when the request does not ask for fields.
+func GeneralListSpec(projection bson.M) pagination.Spec {
+	return pagination.Spec{
+		Sorts: map[string]string{
+			"id":             "_id",
+			"name":           "general.name",
+			"version":        "general.version",
+			"gtype":          "general.gtype",
+			"canonical_name": "general.canonical_name",
+			"created_at":     "general.created_at",
+			"updated_at":     "general.updated_at",
+		},
+		DefaultSort: "name",
+		Fields: map[string]string{
+			"name":             "general.name",
+			"version":          "general.version",
+			"type":             "general.type",
+			"gtype":            "general.gtype",
+			"project":          "general.project",
+			"collection":       "general.collection",
+			"canonical_name":   "general.canonical_name",
+			"category":         "general.category",
+			"managed":          "general.managed",
+			"metadata":         "general.metadata",
+			"labels":           "general.labels",
+			"permissions":      "general.permissions",
+			"config_discovery": "general.config_discovery",
+			"typed_config":     "general.typed_config",
+			"created_at":       "general.created_at",
+			"updated_at":       "general.updated_at",
+			"updated_by":       "general.updated_by",
+		},
+		Keep:       []string{"general.name"},
+		Projection: projection,
+	}
+}
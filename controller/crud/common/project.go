@@ -0,0 +1,32 @@
+package common
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+// GetProjectSettings returns the settings of the project with defaults applied.
+// A missing project or settings document yields the defaults.
+func GetProjectSettings(ctx context.Context, appCtx *db.AppContext, projectID string) models.ProjectSettings {
+	var project struct {
+		Settings *models.ProjectSettings `bson:"settings"`
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return project.Settings.WithDefaults()
+	}
+
+	opts := options.FindOne().SetProjection(bson.M{"settings": 1})
+	if err := appCtx.Client.Collection("projects").FindOne(ctx, bson.M{"_id": objectID}, opts).Decode(&project); err != nil {
+		appCtx.Logger.Debugf("project settings could not be loaded for %s: %v", projectID, err)
+	}
+
+	return project.Settings.WithDefaults()
+}
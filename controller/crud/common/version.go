@@ -0,0 +1,65 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+// ExpectedVersion returns the version an update is based on. The If-Match header
+// wins over the version in the body.
+func ExpectedVersion(resource models.ResourceClass, requestDetails models.RequestDetails) (int, error) {
+	raw := requestDetails.IfMatch
+	if raw == "" {
+		raw, _ = resource.GetVersion().(string)
+	}
+
+	if raw == "" {
+		return 0, errstr.ErrVersionRequired
+	}
+
+	version, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", errstr.ErrInvalidVersion, raw)
+	}
+	return version, nil
+}
+
+// VersionConflict builds the conflict error from the stored version and last editor.
+func VersionConflict(ctx context.Context, collection *mongo.Collection, filter bson.M) error {
+	var current struct {
+		General struct {
+			UpdatedBy string `bson:"updated_by"`
+		} `bson:"general"`
+		Resource struct {
+			Version string `bson:"version"`
+		} `bson:"resource"`
+	}
+
+	opts := options.FindOne().SetProjection(bson.M{"general.updated_by": 1, "resource.version": 1})
+	if err := collection.FindOne(ctx, filter, opts).Decode(&current); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return errstr.ErrNoDocumentsUpdate
+		}
+		return errstr.ErrUnknownDBError
+	}
+
+	return &errstr.ConflictError{CurrentVersion: current.Resource.Version, UpdatedBy: current.General.UpdatedBy}
+}
+
+// VersionedFilter copies the filter and pins it to the expected version.
+func VersionedFilter(filter bson.M, expected int) bson.M {
+	versioned := bson.M{}
+	maps.Copy(versioned, filter)
+	versioned["resource.version"] = strconv.Itoa(expected)
+	return versioned
+}
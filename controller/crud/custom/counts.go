@@ -9,6 +9,7 @@ import (
 
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/labels"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 )
 
@@ -16,14 +17,20 @@ func (custom *AppHandler) GetResourceCounts(ctx context.Context, _ models.Resour
 	results := make(map[string]int64)
 	var collections []string
 
+	if _, err := labels.Parse(requestDetails.Selector); err != nil {
+		return nil, err
+	}
+
 	for key := range db.Indices {
 		collections = append(collections, key)
 	}
 
 	for _, collectionName := range collections {
 		collection := custom.Context.Client.Collection(collectionName)
-		filter := bson.M{"general.project": requestDetails.Project}
-		filter = common.AddUserFilter(requestDetails, filter)
+		collectionDetails := requestDetails
+		collectionDetails.Collection = collectionName
+		filter, _ := common.AddSelectorFilter(collectionDetails, bson.M{"general.project": requestDetails.Project})
+		filter = common.AddUserFilter(collectionDetails, filter)
 
 		count, err := collection.CountDocuments(ctx, filter)
 		if err != nil {
@@ -42,7 +49,10 @@ func (custom *AppHandler) GetFilterCounts(ctx context.Context, _ models.Resource
 		groupKey = "$general.category"
 	}
 	collection := custom.Context.Client.Collection(requestDetails.Collection)
-	filter := bson.M{"general.project": requestDetails.Project}
+	filter, err := common.AddSelectorFilter(requestDetails, bson.M{"general.project": requestDetails.Project})
+	if err != nil {
+		return nil, err
+	}
 	filter = common.AddUserFilter(requestDetails, filter)
 
 	pipeline := mongo.Pipeline{
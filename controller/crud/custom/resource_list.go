@@ -6,11 +6,10 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/pagination"
 )
 
 type Record struct {
@@ -22,31 +21,29 @@ type Record struct {
 	Collection    string `json:"collection" bson:"collection"`
 }
 
+// recordProjection is what the custom lists read from a document.
+var recordProjection = bson.M{
+	"general.name":           1,
+	"general.canonical_name": 1,
+	"general.gtype":          1,
+	"general.type":           1,
+	"general.category":       1,
+}
+
 func (custom *AppHandler) GetCustomResourceList(ctx context.Context, _ models.ResourceClass, requestDetails models.RequestDetails) (any, error) {
 	collection := custom.Context.Client.Collection(requestDetails.Collection)
 
-	opts := options.Find().SetProjection(bson.M{
-		"general.name":           1,
-		"general.canonical_name": 1,
-		"general.gtype":          1,
-		"general.type":           1,
-		"general.category":       1,
-	})
-
-	filters := buildFilters(requestDetails)
+	filters, err := common.AddSelectorFilter(requestDetails, buildFilters(requestDetails))
+	if err != nil {
+		return nil, err
+	}
 	filters = common.AddUserFilter(requestDetails, filters)
-	cursor, err := collection.Find(ctx, filters, opts)
+	result, err := pagination.Find(ctx, collection, filters, requestDetails.List, common.GeneralListSpec(recordProjection))
 	if err != nil {
 		return nil, fmt.Errorf("db error: %w", err)
 	}
-	defer cursor.Close(ctx)
 
-	results, decodeErr := decodeResults(ctx, cursor, requestDetails.Collection, custom.Logger.Logger)
-	if decodeErr != nil {
-		return nil, decodeErr
-	}
-
-	return results, nil
+	return result.Wrap(decodeRecords(result.Records, requestDetails.Collection, custom.Logger.Logger)), nil
 }
 
 func buildFilters(details models.RequestDetails) bson.M {
@@ -79,10 +76,10 @@ func buildFilters(details models.RequestDetails) bson.M {
 	return filters
 }
 
-func decodeResults(ctx context.Context, cursor *mongo.Cursor, collectionName string, logger *logrus.Logger) ([]Record, error) {
-	var results []Record
+func decodeRecords(records []bson.M, collectionName string, logger *logrus.Logger) []Record {
+	results := make([]Record, 0, len(records))
 
-	for cursor.Next(ctx) {
+	for _, record := range records {
 		var doc struct {
 			General struct {
 				Name          string `bson:"name"`
@@ -93,7 +90,11 @@ func decodeResults(ctx context.Context, cursor *mongo.Cursor, collectionName str
 			} `bson:"general"`
 		}
 
-		if err := cursor.Decode(&doc); err != nil {
+		bsonBytes, err := bson.Marshal(record)
+		if err == nil {
+			err = bson.Unmarshal(bsonBytes, &doc)
+		}
+		if err != nil {
 			logger.Debugf("Decode fail: %v", err)
 			continue
 		}
@@ -108,10 +109,5 @@ func decodeResults(ctx context.Context, cursor *mongo.Cursor, collectionName str
 		})
 	}
 
-	if err := cursor.Err(); err != nil {
-		logger.Debugf("Cursor error: %v", err)
-		return nil, err
-	}
-
-	return results, nil
+	return results
 }
@@ -0,0 +1,260 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/controller/poker"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/drafts"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
+)
+
+type DraftDetails struct {
+	Draft       *drafts.Draft              `json:"draft"`
+	LiveVersion string                     `json:"live_version"`
+	Stale       bool                       `json:"stale"`
+	Patch       []revisions.PatchOperation `json:"patch"`
+}
+
+type PublishResult struct {
+	Published []drafts.Draft   `json:"published"`
+	Changes   *poker.Processed `json:"changes,omitempty"`
+}
+
+func (dh *AppHandler) ListDrafts(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+	pending, err := drafts.List(ctx, dh.Context.Client, drafts.ListFilter{
+		Project:    requestDetails.Project,
+		Collection: requestDetails.Collection,
+		Version:    requestDetails.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dh.accessible(ctx, requestDetails, pending)
+}
+
+// GetDraft returns the draft with the json patch from the live resource to the draft.
+func (dh *AppHandler) GetDraft(ctx context.Context, requestDetails models.RequestDetails, draftID string) (any, error) {
+	draft, err := drafts.Get(ctx, dh.Context.Client, draftID, requestDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := dh.loadLive(ctx, requestDetails, draft)
+	if err != nil {
+		return nil, err
+	}
+
+	details := DraftDetails{
+		Draft:       draft,
+		LiveVersion: live.Resource.Version,
+		Stale:       live.Resource.Version != draft.BaseVersion,
+	}
+
+	details.Patch, err = revisions.Diff(
+		map[string]any{"general": live.General, "resource": live.Resource.Resource},
+		map[string]any{"general": draft.General, "resource": draft.Resource},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// DiscardDraft drops a draft, only its author or an owner may discard it.
+func (dh *AppHandler) DiscardDraft(ctx context.Context, requestDetails models.RequestDetails, draftID string) (any, error) {
+	draft, err := drafts.Get(ctx, dh.Context.Client, draftID, requestDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dh.loadLive(ctx, requestDetails, draft); err != nil {
+		return nil, err
+	}
+	if !requestDetails.User.IsOwner && draft.UpdatedBy != requestDetails.User.UserName {
+		return nil, fmt.Errorf("only %s or an owner can discard this draft", draft.UpdatedBy)
+	}
+
+	if err := drafts.Delete(ctx, dh.Context.Client, draft.ID); err != nil {
+		return nil, err
+	}
+	return map[string]any{"message": "Success"}, nil
+}
+
+// PublishDrafts promotes the selected drafts, or every draft of the project when
+// none is selected, to live in one transaction and pokes the affected listeners once.
+// A draft whose live resource changed since it was saved fails the whole publish.
+func (dh *AppHandler) PublishDrafts(ctx context.Context, requestDetails models.RequestDetails, draftIDs []string) (any, error) {
+	selected, err := dh.selectDrafts(ctx, requestDetails, draftIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(selected) == 0 {
+		return nil, errors.New("there are no drafts to publish")
+	}
+
+	session, err := dh.Context.Client.Client().StartSession()
+	if err != nil {
+		return nil, fmt.Errorf("could not start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	promoted := make([]models.ResourceClass, 0, len(selected))
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		promoted = promoted[:0]
+		for _, draft := range selected {
+			resource, err := dh.promote(sc, draft, requestDetails)
+			if err != nil {
+				return nil, fmt.Errorf("draft %s/%s: %w", draft.Collection, draft.Name, err)
+			}
+			promoted = append(promoted, resource)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := PublishResult{Published: make([]drafts.Draft, 0, len(selected))}
+	for _, draft := range selected {
+		summary := *draft
+		summary.General = nil
+		summary.Resource = nil
+		result.Published = append(result.Published, summary)
+	}
+
+	result.Changes = crud.PublishChanges(ctx, dh.Context, promoted, dh.XDS.PokeService)
+	return result, nil
+}
+
+func (dh *AppHandler) selectDrafts(ctx context.Context, requestDetails models.RequestDetails, draftIDs []string) ([]*drafts.Draft, error) {
+	if len(draftIDs) == 0 {
+		pending, err := drafts.List(ctx, dh.Context.Client, drafts.ListFilter{Project: requestDetails.Project, Version: requestDetails.Version})
+		if err != nil {
+			return nil, err
+		}
+		pending, err = dh.accessible(ctx, requestDetails, pending)
+		if err != nil {
+			return nil, err
+		}
+		for _, draft := range pending {
+			draftIDs = append(draftIDs, draft.ID.Hex())
+		}
+	}
+
+	selected := make([]*drafts.Draft, 0, len(draftIDs))
+	for _, id := range draftIDs {
+		draft, err := drafts.Get(ctx, dh.Context.Client, id, requestDetails.Project)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		if _, err := dh.loadLive(ctx, requestDetails, draft); err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		selected = append(selected, draft)
+	}
+	return selected, nil
+}
+
+// promote writes the draft through the regular update path and removes it.
+func (dh *AppHandler) promote(ctx context.Context, draft *drafts.Draft, requestDetails models.RequestDetails) (models.ResourceClass, error) {
+	resource, err := draft.DBResource()
+	if err != nil {
+		return nil, err
+	}
+
+	details := requestDetails
+	details.Name = draft.Name
+	details.Collection = draft.Collection
+	details.Version = draft.Version
+	details.GType = draft.GType
+	details.CanonicalName = resource.General.CanonicalName
+	details.ResourceID = draft.ResourceID.Hex()
+	details.IfMatch = ""
+	details.SaveOrPublish = models.SaveLive
+
+	if helper.Contains([]string{"filters", "extensions"}, draft.Collection) {
+		_, err = dh.Extension.UpdateExtensions(ctx, resource, details)
+	} else {
+		_, err = dh.XDS.UpdateResource(ctx, resource, details)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := drafts.Delete(ctx, dh.Context.Client, draft.ID); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// loadLive reads the live resource of the draft, a draft of a resource the user
+// cannot read is not found.
+func (dh *AppHandler) loadLive(ctx context.Context, requestDetails models.RequestDetails, draft *drafts.Draft) (*models.DBResource, error) {
+	details := requestDetails
+	details.Collection = draft.Collection
+	filter := common.AddUserFilter(details, bson.M{"_id": draft.ResourceID})
+
+	live, err := revisions.LoadResource(ctx, dh.Context.Client, draft.Collection, filter)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, drafts.ErrDraftNotFound
+		}
+		return nil, err
+	}
+	return live, nil
+}
+
+// accessible keeps the drafts of the live resources the user can read.
+func (dh *AppHandler) accessible(ctx context.Context, requestDetails models.RequestDetails, pending []drafts.Draft) ([]drafts.Draft, error) {
+	if requestDetails.User.IsOwner || requestDetails.User.Role == models.RoleAdmin {
+		return pending, nil
+	}
+
+	byCollection := map[string][]primitive.ObjectID{}
+	for _, draft := range pending {
+		byCollection[draft.Collection] = append(byCollection[draft.Collection], draft.ResourceID)
+	}
+
+	readable := map[primitive.ObjectID]bool{}
+	for collection, ids := range byCollection {
+		details := requestDetails
+		details.Collection = collection
+		filter := common.AddUserFilter(details, bson.M{"_id": bson.M{"$in": ids}})
+
+		opts := options.Find().SetProjection(bson.M{"_id": 1})
+		cursor, err := dh.Context.Client.Collection(collection).Find(ctx, filter, opts)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", collection, err)
+		}
+		var found []struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.All(ctx, &found); err != nil {
+			return nil, fmt.Errorf("could not decode %s: %w", collection, err)
+		}
+		for _, resource := range found {
+			readable[resource.ID] = true
+		}
+	}
+
+	result := []drafts.Draft{}
+	for _, draft := range pending {
+		if readable[draft.ResourceID] {
+			result = append(result, draft)
+		}
+	}
+	return result, nil
+}
@@ -9,15 +9,24 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/drafts"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models/downstreamfilters"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
 )
 
 func (xds *AppHandler) DelExtension(ctx context.Context, _ models.ResourceClass, requestDetails models.RequestDetails) (any, error) {
 	resourceType := requestDetails.Collection
 	collection := xds.Context.Client.Collection(resourceType)
+	if err := crud.CheckPermanentDelete(requestDetails); err != nil {
+		return nil, err
+	}
+
 	filter, err := common.AddResourceIDFilter(requestDetails, buildFilter(requestDetails))
 	if err != nil {
 		return nil, errors.New("invalid id format")
@@ -46,10 +55,39 @@ func (xds *AppHandler) DelExtension(ctx context.Context, _ models.ResourceClass,
 		return nil, err
 	}
 
-	if err := deleteDocument(ctx, xds, collection, filter); err != nil {
+	deleted, err := revisions.LoadResource(ctx, xds.Context.Client, resourceType, filter)
+	if err != nil {
+		xds.Logger.Errorf("Could not load resource before delete: %v", err)
+	}
+
+	err = crud.InTransaction(ctx, xds.Context, func(sc context.Context) error {
+		if err := crud.MoveToTrash(sc, xds.Context, requestDetails, resourceType, filter); err != nil {
+			return err
+		}
+		return deleteDocument(sc, xds, collection, filter)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	if deleted != nil {
+		crud.RecordRevision(ctx, xds.Context, revisions.OperationDelete, deleted, requestDetails.User)
+	}
+
+	if err := resources.RemoveReferences(ctx, xds.Context, resourceType, requestDetails.Name, requestDetails.Project, requestDetails.Version); err != nil {
+		xds.Logger.Errorf("Could not remove references: %v", err)
+	}
+	if err := drafts.RemoveFor(ctx, xds.Context.Client, resourceType, requestDetails.Name, requestDetails.Project, requestDetails.Version); err != nil {
+		xds.Logger.Errorf("%v", err)
+	}
+
+	crud.Invalidate(ctx, xds.Context, invalidation.Event{
+		Collection: resourceType,
+		Name:       requestDetails.Name,
+		Project:    requestDetails.Project,
+		Version:    requestDetails.Version,
+	})
+
 	return gin.H{"message": "Success"}, nil
 }
 
@@ -2,33 +2,34 @@ package extension
 
 import (
 	"context"
+	"errors"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/pagination"
 )
 
 func (extension *AppHandler) ListExtensions(ctx context.Context, _ models.ResourceClass, requestDetails models.RequestDetails) (any, error) {
-	var records []bson.M
 	collection := extension.Context.Client.Collection(requestDetails.Collection)
 	filter := bson.M{"general.canonical_name": requestDetails.CanonicalName, "general.project": requestDetails.Project}
-	filterWithRestriction := common.AddUserFilter(requestDetails, filter)
-
-	opts := options.Find().SetProjection(bson.M{"resource": 0})
-
-	cursor, err := collection.Find(ctx, filterWithRestriction, opts)
+	filter, err := common.AddSelectorFilter(requestDetails, filter)
 	if err != nil {
-		return nil, errstr.ErrUnknownDBError
+		return nil, err
 	}
+	filterWithRestriction := common.AddUserFilter(requestDetails, filter)
 
-	if err = cursor.All(ctx, &records); err != nil {
+	result, err := pagination.Find(ctx, collection, filterWithRestriction, requestDetails.List, common.GeneralListSpec(bson.M{"resource": 0}))
+	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidOptions) {
+			return nil, err
+		}
 		return nil, errstr.ErrUnknownDBError
 	}
 
-	generals := common.TransformGenerals(records)
+	generals := common.TransformGenerals(result.Records)
 
-	return generals, nil
+	return result.Wrap(generals), nil
 }
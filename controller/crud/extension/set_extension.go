@@ -3,13 +3,17 @@ package extension
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
 )
 
 func (extension *AppHandler) SetExtension(ctx context.Context, resource models.ResourceClass, requestDetails models.RequestDetails) (any, error) {
@@ -20,21 +24,50 @@ func (extension *AppHandler) SetExtension(ctx context.Context, resource models.R
 		return nil, err
 	}
 
-	collection := extension.Context.Client.Collection(general.Collection)
-	inserResult, err := collection.InsertOne(ctx, resource)
+	warnings, err := resources.CheckReferences(ctx, extension.Context, resource, extension.Logger.Logger)
 	if err != nil {
-		if er := new(mongo.WriteException); errors.As(err, &er) && er.WriteErrors[0].Code == 11000 {
-			return nil, errstr.ErrNameAlreadyExists
+		return nil, err
+	}
+
+	findings, err := resources.CheckLint(ctx, extension.Context, resource, extension.Logger.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var inserResult *mongo.InsertOneResult
+	err = crud.InTransaction(ctx, extension.Context, func(sc context.Context) error {
+		collection := extension.Context.Client.Collection(general.Collection)
+		var err error
+		inserResult, err = collection.InsertOne(sc, resource)
+		if err != nil {
+			if er := new(mongo.WriteException); errors.As(err, &er) && er.WriteErrors[0].Code == 11000 {
+				return errstr.ErrNameAlreadyExists
+			}
+			return err
+		}
+
+		if err := resources.IndexReferences(sc, extension.Context, resource, extension.Logger.Logger); err != nil {
+			return fmt.Errorf("could not index references: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
+	crud.RecordRevision(ctx, extension.Context, revisions.OperationCreate, resource, requestDetails.User)
+
+	crud.Invalidate(ctx, extension.Context, invalidation.Event{
+		Collection: general.Collection,
+		Name:       general.Name,
+		Project:    general.Project,
+		Version:    general.Version,
+	})
 
 	if oid, ok := inserResult.InsertedID.(primitive.ObjectID); ok {
 		resource.SetID(oid)
 		resourceID = oid.Hex()
 	}
 
-	data := map[string]any{"resource_id": resourceID}
-
+	data := crud.WithWarnings(map[string]any{"resource_id": resourceID}, warnings, findings)
 	return map[string]any{"message": "Success", "data": data}, nil
 }
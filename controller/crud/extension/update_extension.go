@@ -13,8 +13,10 @@ import (
 
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
 )
 
 func (extension *AppHandler) UpdateFilters(ctx context.Context, resource models.ResourceClass, requestDetails models.RequestDetails) (any, error) {
@@ -44,16 +46,17 @@ func updateResource(ctx context.Context, extension *AppHandler, resource models.
 	}
 
 	filterWithRestriction := common.AddUserFilter(requestDetails, filter)
-	versionStr, ok := resource.GetVersion().(string)
-	if !ok {
-		extension.Logger.Warnf("expected string type for version, got %v", resource.GetVersion())
-		return nil, fmt.Errorf("invalid version format: %v", resource.GetVersion())
+	version, err := common.ExpectedVersion(resource, requestDetails)
+	if err != nil {
+		return nil, err
 	}
 
-	version, err := strconv.Atoi(versionStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid version format: %s", versionStr)
+	collection := extension.Context.Client.Collection(requestDetails.Collection)
+	versionedFilter := common.VersionedFilter(filterWithRestriction, version)
+	if err := collection.FindOne(ctx, versionedFilter).Err(); err != nil {
+		return nil, common.VersionConflict(ctx, collection, filterWithRestriction)
 	}
+
 	resource.SetVersion(strconv.Itoa(version + 1))
 	newResource := resource.GetResource()
 	nodeid := fmt.Sprintf("%s::%s", requestDetails.Name, requestDetails.Project)
@@ -71,6 +74,24 @@ func updateResource(ctx context.Context, extension *AppHandler, resource models.
 
 	resource.SetTypedConfig(resources.DecodeSetTypedConfigs(resource, extension.Logger.Logger))
 
+	warnings, err := resources.CheckReferences(ctx, extension.Context, resource, extension.Logger.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := resources.CheckLint(ctx, extension.Context, resource, extension.Logger.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if requestDetails.SaveOrPublish == models.SaveDraft {
+		draft, err := crud.SaveDraft(ctx, extension.Context, collection, versionedFilter, resource, version, requestDetails.User)
+		if err != nil {
+			return nil, err
+		}
+		return crud.WithWarnings(gin.H{"message": "Success", "data": gin.H{"draft": draft}}, warnings, findings), nil
+	}
+
 	update := bson.M{
 		"$set": bson.M{
 			"resource.resource":        newResource,
@@ -78,17 +99,40 @@ func updateResource(ctx context.Context, extension *AppHandler, resource models.
 			"general.config_discovery": resource.GetConfigDiscovery(),
 			"general.updated_at":       primitive.NewDateTimeFromTime(time.Now()),
 			"general.typed_config":     resource.GetTypedConfig(),
+			"general.updated_by":       requestDetails.User.UserName,
 		},
 	}
 
-	collection := extension.Context.Client.Collection(requestDetails.Collection)
-	_, err = collection.UpdateOne(ctx, filterWithRestriction, update)
+	err = crud.InTransaction(ctx, extension.Context, func(sc context.Context) error {
+		updateResult, err := collection.UpdateOne(sc, versionedFilter, update)
+		if err != nil {
+			return fmt.Errorf("update failed: %w", err)
+		}
+
+		if updateResult.MatchedCount == 0 {
+			return common.VersionConflict(sc, collection, filterWithRestriction)
+		}
+
+		if err := resources.IndexReferences(sc, extension.Context, resource, extension.Logger.Logger); err != nil {
+			return fmt.Errorf("could not index references: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("update failed: %w", err)
+		return nil, err
 	}
+	crud.RecordRevision(ctx, extension.Context, revisions.OperationUpdate, resource, requestDetails.User)
+
+	general := resource.GetGeneral()
+	crud.Invalidate(ctx, extension.Context, invalidation.Event{
+		Collection: requestDetails.Collection,
+		Name:       general.Name,
+		Project:    requestDetails.Project,
+		Version:    general.Version,
+	})
 
 	project := resource.GetGeneral().Project
 	changedResources := crud.HandleResourceChange(ctx, resource, requestDetails, extension.Context, project, extension.PokeService)
 
-	return gin.H{"message": "Success", "data": changedResources}, nil
+	return crud.WithWarnings(gin.H{"message": "Success", "data": changedResources}, warnings, findings), nil
 }
@@ -0,0 +1,47 @@
+package lints
+
+import (
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/lint"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+type AppHandler struct {
+	Context *db.AppContext
+	Logger  *logger.Logger
+}
+
+func NewLintHandler(context *db.AppContext) *AppHandler {
+	return &AppHandler{
+		Context: context,
+		Logger:  logger.NewLogger("controller/lint"),
+	}
+}
+
+// RuleInfo describes a lint rule, Severity is the one in effect for the project.
+type RuleInfo struct {
+	ID              string          `json:"id"`
+	Description     string          `json:"description"`
+	GTypes          []models.GTypes `json:"gtypes"`
+	DefaultSeverity string          `json:"default_severity"`
+	Severity        string          `json:"severity"`
+}
+
+// Report lists the findings of one resource.
+type Report struct {
+	Collection string         `json:"collection"`
+	Name       string         `json:"name"`
+	GType      models.GTypes  `json:"gtype"`
+	Findings   []lint.Finding `json:"findings"`
+}
+
+// ProjectReport lists the resources of a project with findings.
+type ProjectReport struct {
+	Project   string   `json:"project"`
+	Version   string   `json:"version"`
+	Checked   int      `json:"checked"`
+	Errors    int      `json:"errors"`
+	Warnings  int      `json:"warnings"`
+	Resources []Report `json:"resources"`
+}
@@ -0,0 +1,127 @@
+package lints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/lint"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+)
+
+// Rules lists the registered rules with the severities of the project.
+func (lh *AppHandler) Rules(ctx context.Context, requestDetails models.RequestDetails) ([]RuleInfo, error) {
+	var severities map[string]string
+	if requestDetails.Project != "" {
+		severities = common.GetProjectSettings(ctx, lh.Context, requestDetails.Project).Lint
+	}
+
+	rules := lint.Rules()
+	result := make([]RuleInfo, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, RuleInfo{
+			ID:              rule.ID(),
+			Description:     rule.Description(),
+			GTypes:          rule.GTypes(),
+			DefaultSeverity: rule.DefaultSeverity(),
+			Severity:        lint.Severity(rule, severities),
+		})
+	}
+	return result, nil
+}
+
+// Resource lints a stored resource.
+func (lh *AppHandler) Resource(ctx context.Context, requestDetails models.RequestDetails) (*Report, error) {
+	switch {
+	case requestDetails.Project == "":
+		return nil, errors.New("project is required")
+	case requestDetails.Version == "":
+		return nil, errors.New("version is required")
+	case !helper.Contains(models.XDSCollections(), requestDetails.Collection):
+		return nil, fmt.Errorf("unknown collection: %s", requestDetails.Collection)
+	}
+
+	filter := common.AddUserFilter(requestDetails, bson.M{"general.name": requestDetails.Name, "general.version": requestDetails.Version})
+	var resource models.DBResource
+	if err := lh.Context.Client.Collection(requestDetails.Collection).FindOne(ctx, filter).Decode(&resource); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s/%s does not exist in version %s", requestDetails.Collection, requestDetails.Name, requestDetails.Version)
+		}
+		return nil, err
+	}
+
+	return lh.lint(ctx, requestDetails.Collection, &resource)
+}
+
+// Project lints every resource of the project and version the user can access,
+// optionally only those of one collection or gtype.
+func (lh *AppHandler) Project(ctx context.Context, requestDetails models.RequestDetails) (*ProjectReport, error) {
+	switch {
+	case requestDetails.Project == "":
+		return nil, errors.New("project is required")
+	case requestDetails.Version == "":
+		return nil, errors.New("version is required")
+	}
+
+	collections := models.XDSCollections()
+	if requestDetails.Collection != "" {
+		if !helper.Contains(collections, requestDetails.Collection) {
+			return nil, fmt.Errorf("unknown collection: %s", requestDetails.Collection)
+		}
+		collections = []string{requestDetails.Collection}
+	}
+
+	report := &ProjectReport{Project: requestDetails.Project, Version: requestDetails.Version, Resources: []Report{}}
+	for _, collection := range collections {
+		details := requestDetails
+		details.Collection = collection
+		filter := bson.M{"general.version": requestDetails.Version}
+		if requestDetails.GType != "" {
+			filter["general.gtype"] = requestDetails.GType
+		}
+
+		cursor, err := lh.Context.Client.Collection(collection).Find(ctx, common.AddUserFilter(details, filter))
+		if err != nil {
+			return nil, err
+		}
+		var found []models.DBResource
+		if err := cursor.All(ctx, &found); err != nil {
+			return nil, err
+		}
+
+		for i := range found {
+			resourceReport, err := lh.lint(ctx, collection, &found[i])
+			if err != nil {
+				return nil, err
+			}
+			report.Checked++
+			if len(resourceReport.Findings) == 0 {
+				continue
+			}
+			for _, finding := range resourceReport.Findings {
+				if finding.Severity == models.LintError {
+					report.Errors++
+				} else {
+					report.Warnings++
+				}
+			}
+			report.Resources = append(report.Resources, *resourceReport)
+		}
+	}
+	return report, nil
+}
+
+func (lh *AppHandler) lint(ctx context.Context, collection string, resource *models.DBResource) (*Report, error) {
+	general := resource.GetGeneral()
+	findings, err := resources.Lint(ctx, lh.Context, resource, lh.Logger.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("%s/%s: %w", collection, general.Name, err)
+	}
+	return &Report{Collection: collection, Name: general.Name, GType: general.GType, Findings: findings}, nil
+}
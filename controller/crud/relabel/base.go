@@ -0,0 +1,31 @@
+package relabel
+
+import (
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+)
+
+type AppHandler struct {
+	Context *db.AppContext
+	Logger  *logger.Logger
+}
+
+func NewRelabelHandler(context *db.AppContext) *AppHandler {
+	return &AppHandler{
+		Context: context,
+		Logger:  logger.NewLogger("controller/relabel"),
+	}
+}
+
+// Change sets and removes labels of a resource, the labels it does not name are kept.
+type Change struct {
+	Set    map[string]string `json:"set"`
+	Remove []string          `json:"remove"`
+}
+
+type Result struct {
+	Collection string            `json:"collection"`
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	Labels     map[string]string `json:"labels"`
+}
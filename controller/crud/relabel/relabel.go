@@ -0,0 +1,171 @@
+package relabel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/labels"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
+)
+
+// Relabel applies the change to the labels of a resource. Labels are not part of the
+// envoy configuration, so nothing is published and the resource version is kept.
+func (lh *AppHandler) Relabel(ctx context.Context, requestDetails models.RequestDetails, change Change) (*Result, error) {
+	if err := checkRequest(requestDetails, change); err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"general.name": requestDetails.Name, "general.project": requestDetails.Project, "general.version": requestDetails.Version}
+	filter = common.AddUserFilter(requestDetails, filter)
+	resource, err := revisions.LoadResource(ctx, lh.Context.Client, requestDetails.Collection, filter)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s/%s does not exist", requestDetails.Collection, requestDetails.Name)
+		}
+		return nil, err
+	}
+
+	updated := make(map[string]string, len(resource.General.Labels)+len(change.Set))
+	maps.Copy(updated, resource.General.Labels)
+	maps.Copy(updated, change.Set)
+	for _, key := range change.Remove {
+		delete(updated, key)
+	}
+
+	// The update only matches while the resource is unchanged since it was read, a
+	// concurrent label change would otherwise be lost.
+	now := primitive.NewDateTimeFromTime(time.Now())
+	update := bson.M{"$set": bson.M{
+		"general.labels":     updated,
+		"general.updated_at": now,
+		"general.updated_by": requestDetails.User.UserName,
+	}}
+	unchanged := bson.M{"_id": resource.ID, "general.updated_at": resource.General.UpdatedAt}
+	if resource.General.UpdatedAt == 0 {
+		unchanged["general.updated_at"] = bson.M{"$in": bson.A{nil, resource.General.UpdatedAt}}
+	}
+	updateResult, err := lh.Context.Client.Collection(requestDetails.Collection).UpdateOne(ctx, unchanged, update)
+	if err != nil {
+		return nil, fmt.Errorf("could not update labels: %w", err)
+	}
+	if updateResult.MatchedCount == 0 {
+		return nil, fmt.Errorf("%s/%s was changed while its labels were updated, retry", requestDetails.Collection, requestDetails.Name)
+	}
+
+	resource.General.Labels = updated
+	resource.General.UpdatedAt = now
+	resource.General.UpdatedBy = requestDetails.User.UserName
+	crud.RecordRevision(ctx, lh.Context, revisions.OperationUpdate, resource, requestDetails.User)
+	lh.Context.Invalidation.Publish(invalidation.Event{
+		Collection: requestDetails.Collection,
+		Name:       requestDetails.Name,
+		Project:    requestDetails.Project,
+		Version:    requestDetails.Version,
+	})
+
+	return &Result{
+		Collection: requestDetails.Collection,
+		Name:       requestDetails.Name,
+		Version:    requestDetails.Version,
+		Labels:     updated,
+	}, nil
+}
+
+func checkRequest(requestDetails models.RequestDetails, change Change) error {
+	switch {
+	case requestDetails.Project == "" || requestDetails.Version == "" || requestDetails.Collection == "" || requestDetails.Name == "":
+		return errors.New("project, version, collection and name are required")
+	case !helper.Contains(models.XDSCollections(), requestDetails.Collection):
+		return fmt.Errorf("unknown collection: %s", requestDetails.Collection)
+	case len(change.Set) == 0 && len(change.Remove) == 0:
+		return errors.New("no labels to set or remove")
+	}
+
+	if err := labels.Validate(change.Set); err != nil {
+		return err
+	}
+	for _, key := range change.Remove {
+		if _, ok := change.Set[key]; ok {
+			return fmt.Errorf("label %q is both set and removed", key)
+		}
+	}
+	return nil
+}
+
+// Keys returns the label keys used in the project with their values, sorted. Without
+// a collection in the request every xDS collection is read.
+func (lh *AppHandler) Keys(ctx context.Context, requestDetails models.RequestDetails) (map[string][]string, error) {
+	if requestDetails.Project == "" {
+		return nil, errors.New("project is required")
+	}
+
+	collections := models.XDSCollections()
+	if requestDetails.Collection != "" {
+		if !helper.Contains(collections, requestDetails.Collection) {
+			return nil, fmt.Errorf("unknown collection: %s", requestDetails.Collection)
+		}
+		collections = []string{requestDetails.Collection}
+	}
+
+	seen := map[string]map[string]struct{}{}
+	for _, collection := range collections {
+		collectionDetails := requestDetails
+		collectionDetails.Collection = collection
+		filter := bson.M{labels.Field: bson.M{"$exists": true}}
+		if requestDetails.Version != "" {
+			filter["general.version"] = requestDetails.Version
+		}
+		filter = common.AddUserFilter(collectionDetails, filter)
+
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: filter}},
+			{{Key: "$project", Value: bson.M{"labels": bson.M{"$objectToArray": "$" + labels.Field}}}},
+			{{Key: "$unwind", Value: "$labels"}},
+			{{Key: "$group", Value: bson.M{"_id": "$labels.k", "values": bson.M{"$addToSet": "$labels.v"}}}},
+		}
+		cursor, err := lh.Context.Client.Collection(collection).Aggregate(ctx, pipeline)
+		if err != nil {
+			return nil, fmt.Errorf("could not read labels of %s: %w", collection, err)
+		}
+
+		var groups []struct {
+			Key    string   `bson:"_id"`
+			Values []string `bson:"values"`
+		}
+		if err := cursor.All(ctx, &groups); err != nil {
+			return nil, fmt.Errorf("could not decode labels of %s: %w", collection, err)
+		}
+		for _, group := range groups {
+			if seen[group.Key] == nil {
+				seen[group.Key] = map[string]struct{}{}
+			}
+			for _, v := range group.Values {
+				seen[group.Key][v] = struct{}{}
+			}
+		}
+	}
+
+	keys := make(map[string][]string, len(seen))
+	for key, values := range seen {
+		sorted := make([]string, 0, len(values))
+		for v := range values {
+			sorted = append(sorted, v)
+		}
+		sort.Strings(sorted)
+		keys[key] = sorted
+	}
+	return keys, nil
+}
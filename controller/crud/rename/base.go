@@ -0,0 +1,43 @@
+package rename
+
+import (
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/extension"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/xds"
+	"github.com/CloudNativeWorks/elchi-backend/controller/poker"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+)
+
+type AppHandler struct {
+	Context   *db.AppContext
+	XDS       *xds.AppHandler
+	Extension *extension.AppHandler
+	Logger    *logger.Logger
+}
+
+func NewRenameHandler(context *db.AppContext, xdsHandler *xds.AppHandler, extensionHandler *extension.AppHandler) *AppHandler {
+	return &AppHandler{
+		Context:   context,
+		XDS:       xdsHandler,
+		Extension: extensionHandler,
+		Logger:    logger.NewLogger("controller/rename"),
+	}
+}
+
+// Referrer is a resource whose references to the renamed resource were rewritten.
+type Referrer struct {
+	Collection string   `json:"collection"`
+	Name       string   `json:"name"`
+	Version    string   `json:"version"`
+	Paths      []string `json:"paths"`
+}
+
+type Result struct {
+	Collection string           `json:"collection"`
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	Versions   []string         `json:"versions"`
+	Referrers  []Referrer       `json:"referrers"`
+	Warnings   []string         `json:"warnings,omitempty"`
+	Published  *poker.Processed `json:"published,omitempty"`
+}
@@ -0,0 +1,376 @@
+package rename
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/drafts"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
+)
+
+// Rename gives a resource a new name and rewrites every reference to it in one
+// transaction, then pokes the affected listeners once. Listeners are renamed in every
+// version together with their bootstrap, service and admin port, like they are deleted.
+func (rh *AppHandler) Rename(ctx context.Context, requestDetails models.RequestDetails, newName string) (*Result, error) {
+	oldName, collection := requestDetails.Name, requestDetails.Collection
+	if err := rh.check(ctx, requestDetails, newName); err != nil {
+		return nil, err
+	}
+
+	versions, err := rh.versions(ctx, requestDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Collection: collection, From: oldName, To: newName, Versions: versions, Referrers: []Referrer{}}
+	referrers := make(map[string][]references.Endpoint, len(versions))
+	for _, version := range versions {
+		if err := rh.checkVersion(ctx, requestDetails, version, newName); err != nil {
+			return nil, err
+		}
+		found, err := references.Referrers(ctx, rh.Context.Client, requestDetails.Project, version, collection, oldName)
+		if err != nil {
+			return nil, err
+		}
+		referrers[version] = found
+	}
+
+	session, err := rh.Context.Client.Client().StartSession()
+	if err != nil {
+		return nil, fmt.Errorf("could not start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	var touched []models.ResourceClass
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		touched = touched[:0]
+		result.Referrers = result.Referrers[:0]
+		result.Warnings = nil
+
+		for _, version := range versions {
+			renamed, err := rh.renameDocument(sc, collection, oldName, newName, requestDetails.Project, version, requestDetails.User)
+			if err != nil {
+				return nil, err
+			}
+			touched = append(touched, renamed)
+
+			if collection == "listeners" {
+				if err := rh.renameBootstrap(sc, oldName, newName, requestDetails.Project, version, requestDetails.User); err != nil {
+					return nil, err
+				}
+			}
+
+			for _, referrer := range referrers[version] {
+				resource, paths, err := rh.rewriteReferrer(sc, referrer, collection, oldName, newName, requestDetails, version)
+				if err != nil {
+					return nil, fmt.Errorf("%s/%s: %w", referrer.Collection, referrer.Name, err)
+				}
+				if resource == nil {
+					continue
+				}
+				touched = append(touched, resource)
+				result.Referrers = append(result.Referrers, Referrer{Collection: referrer.Collection, Name: referrer.Name, Version: version, Paths: paths})
+			}
+		}
+
+		if collection == "listeners" {
+			warnings, err := rh.renameListenerRecords(sc, oldName, newName, requestDetails.Project)
+			if err != nil {
+				return nil, err
+			}
+			result.Warnings = warnings
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		for _, name := range []string{oldName, newName} {
+			rh.Context.Invalidation.Publish(invalidation.Event{Collection: collection, Name: name, Project: requestDetails.Project, Version: version})
+		}
+	}
+
+	result.Published = crud.PublishChanges(ctx, rh.Context, touched, rh.XDS.PokeService)
+	return result, nil
+}
+
+func (rh *AppHandler) check(ctx context.Context, requestDetails models.RequestDetails, newName string) error {
+	switch {
+	case requestDetails.Project == "" || requestDetails.Collection == "" || requestDetails.Name == "":
+		return errors.New("project, collection and name are required")
+	case newName == "":
+		return errors.New("new name is required")
+	case newName == requestDetails.Name:
+		return errors.New("new name is the same as the current name")
+	case requestDetails.Collection == "bootstrap":
+		return errors.New("a bootstrap is renamed together with its listener")
+	case !helper.Contains(models.XDSCollections(), requestDetails.Collection):
+		return fmt.Errorf("unknown collection: %s", requestDetails.Collection)
+	case requestDetails.Collection != "listeners" && requestDetails.Version == "":
+		return errors.New("version is required")
+	case !references.Ready(ctx, rh.Context.Client):
+		return errors.New("the reference index is still being built, try again later")
+	}
+
+	isDefault, err := common.IsDefaultResource(ctx, rh.Context, requestDetails.Name, requestDetails.Collection, requestDetails.Project)
+	if err != nil {
+		return err
+	}
+	if isDefault {
+		return errors.New("default resources cannot be renamed")
+	}
+	return nil
+}
+
+// versions returns the versions the rename applies to, every version of a listener
+// since services and bootstraps are shared by its versions.
+func (rh *AppHandler) versions(ctx context.Context, requestDetails models.RequestDetails) ([]string, error) {
+	if requestDetails.Collection != "listeners" {
+		return []string{requestDetails.Version}, nil
+	}
+
+	filter := common.AddUserFilter(requestDetails, bson.M{"general.name": requestDetails.Name, "general.project": requestDetails.Project})
+	values, err := rh.Context.Client.Collection("listeners").Distinct(ctx, "general.version", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0, len(values))
+	for _, value := range values {
+		if version, ok := value.(string); ok {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("listeners/%s does not exist", requestDetails.Name)
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+
+func (rh *AppHandler) checkVersion(ctx context.Context, requestDetails models.RequestDetails, version, newName string) error {
+	collection := rh.Context.Client.Collection(requestDetails.Collection)
+	filter := bson.M{"general.name": requestDetails.Name, "general.project": requestDetails.Project, "general.version": version}
+	if err := collection.FindOne(ctx, common.AddUserFilter(requestDetails, filter)).Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("%s/%s does not exist in version %s", requestDetails.Collection, requestDetails.Name, version)
+		}
+		return err
+	}
+
+	taken := bson.M{"general.name": newName, "general.project": requestDetails.Project, "general.version": version}
+	count, err := collection.CountDocuments(ctx, taken)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%s/%s already exists in version %s", requestDetails.Collection, newName, version)
+	}
+
+	pending, err := drafts.List(ctx, rh.Context.Client, drafts.ListFilter{
+		Project:    requestDetails.Project,
+		Collection: requestDetails.Collection,
+		Name:       requestDetails.Name,
+		Version:    version,
+	})
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%s/%s has a pending draft, publish or discard it first", requestDetails.Collection, requestDetails.Name)
+	}
+	return nil
+}
+
+// renameDocument renames the live resource, in general and in the body envoy sees,
+// and moves its outgoing references to the new name.
+func (rh *AppHandler) renameDocument(ctx context.Context, collection, oldName, newName, project, version string, user models.UserDetails) (models.ResourceClass, error) {
+	filter := bson.M{"general.name": oldName, "general.project": project, "general.version": version}
+	renamed, err := revisions.LoadResource(ctx, rh.Context.Client, collection, filter)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s/%s does not exist in version %s", collection, oldName, version)
+		}
+		return nil, err
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	body := resources.RenameBody(renamed.GetResource(), oldName, newName)
+	update := bson.M{"$set": bson.M{
+		"general.name":       newName,
+		"general.updated_at": now,
+		"general.updated_by": user.UserName,
+		"resource.resource":  body,
+	}}
+	if _, err := rh.Context.Client.Collection(collection).UpdateOne(ctx, bson.M{"_id": renamed.ID}, update); err != nil {
+		return nil, err
+	}
+
+	renamed.General.Name = newName
+	renamed.General.UpdatedAt = now
+	renamed.General.UpdatedBy = user.UserName
+	renamed.SetResource(body)
+
+	if err := resources.RemoveReferences(ctx, rh.Context, collection, oldName, project, version); err != nil {
+		return nil, err
+	}
+	if err := resources.IndexReferences(ctx, rh.Context, renamed, rh.Logger.Logger); err != nil {
+		return nil, err
+	}
+	crud.RecordRevision(ctx, rh.Context, revisions.OperationUpdate, renamed, user)
+	return renamed, nil
+}
+
+// renameBootstrap renames the bootstrap of a listener and points its node id at the new name.
+func (rh *AppHandler) renameBootstrap(ctx context.Context, oldName, newName, project, version string, user models.UserDetails) error {
+	filter := bson.M{"general.name": oldName, "general.project": project, "general.version": version}
+	bootstrap, err := revisions.LoadResource(ctx, rh.Context.Client, "bootstrap", filter)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		return err
+	}
+
+	body, err := retargetNodeID(bootstrap.GetResource(), oldName+"::"+project, newName+"::"+project, rh.Logger.Logger)
+	if err != nil {
+		return fmt.Errorf("could not rewrite bootstrap node id: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{
+		"general.name":       newName,
+		"general.updated_at": primitive.NewDateTimeFromTime(time.Now()),
+		"general.updated_by": user.UserName,
+		"resource.resource":  body,
+	}}
+	if _, err := rh.Context.Client.Collection("bootstrap").UpdateOne(ctx, bson.M{"_id": bootstrap.ID}, update); err != nil {
+		return err
+	}
+
+	bootstrap.General.Name = newName
+	bootstrap.SetResource(body)
+	if err := resources.RemoveReferences(ctx, rh.Context, "bootstrap", oldName, project, version); err != nil {
+		return err
+	}
+	if err := resources.IndexReferences(ctx, rh.Context, bootstrap, rh.Logger.Logger); err != nil {
+		return err
+	}
+	crud.RecordRevision(ctx, rh.Context, revisions.OperationUpdate, bootstrap, user)
+	return nil
+}
+
+// rewriteReferrer points the references of a referrer at the new name and saves it
+// through the regular update path, which validates it and indexes its references.
+func (rh *AppHandler) rewriteReferrer(ctx context.Context, referrer references.Endpoint, collection, oldName, newName string, requestDetails models.RequestDetails, version string) (models.ResourceClass, []string, error) {
+	filter := bson.M{"general.name": referrer.Name, "general.project": requestDetails.Project, "general.version": version}
+	resource, err := revisions.LoadResource(ctx, rh.Context.Client, referrer.Collection, filter)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	paths, err := resources.RewriteReferences(resource, collection, oldName, newName, rh.Logger.Logger)
+	if err != nil || len(paths) == 0 {
+		return nil, nil, err
+	}
+
+	details := requestDetails
+	details.Name = referrer.Name
+	details.Collection = referrer.Collection
+	details.Version = version
+	details.GType = resource.General.GType
+	details.CanonicalName = resource.General.CanonicalName
+	details.ResourceID = resource.ID.Hex()
+	details.IfMatch = ""
+	details.SaveOrPublish = models.SaveLive
+
+	if helper.Contains([]string{"filters", "extensions"}, referrer.Collection) {
+		_, err = rh.Extension.UpdateExtensions(ctx, resource, details)
+	} else {
+		_, err = rh.XDS.UpdateResource(ctx, resource, details)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return resource, paths, nil
+}
+
+// renameListenerRecords moves the service and admin port of a listener to the new name.
+func (rh *AppHandler) renameListenerRecords(ctx context.Context, oldName, newName, project string) ([]string, error) {
+	filter := bson.M{"name": oldName, "project": project}
+	update := bson.M{"$set": bson.M{"name": newName}}
+
+	var service models.Service
+	err := rh.Context.Client.Collection("services").FindOneAndUpdate(ctx, filter, update).Decode(&service)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("could not rename service: %w", err)
+	}
+
+	if _, err := rh.Context.Client.Collection("admin_ports").UpdateOne(ctx, filter, update); err != nil {
+		return nil, fmt.Errorf("could not rename admin port: %w", err)
+	}
+
+	var warnings []string
+	if len(service.Clients) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d envoys run the bootstrap with node id %s::%s and have to be deployed again", len(service.Clients), oldName, project))
+	}
+	return warnings, nil
+}
+
+// retargetNodeID replaces the node id in the node and the ads metadata of a bootstrap.
+func retargetNodeID(body any, oldNodeID, newNodeID string, logger *logrus.Logger) (any, error) {
+	data, err := helper.MarshalJSON(body, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, path := range []string{"node.id", "node.cluster"} {
+		if gjson.Get(data, path).String() == oldNodeID {
+			paths = append(paths, path)
+		}
+	}
+	gjson.Get(data, "dynamic_resources.ads_config.grpc_services").ForEach(func(i, service gjson.Result) bool {
+		service.Get("initial_metadata").ForEach(func(j, entry gjson.Result) bool {
+			if entry.Get("key").String() == "nodeid" && entry.Get("value").String() == oldNodeID {
+				paths = append(paths, fmt.Sprintf("dynamic_resources.ads_config.grpc_services.%d.initial_metadata.%d.value", i.Int(), j.Int()))
+			}
+			return true
+		})
+		return true
+	})
+
+	for _, path := range paths {
+		if data, err = sjson.Set(data, path, newNodeID); err != nil {
+			return nil, fmt.Errorf("could not set %s: %w", path, err)
+		}
+	}
+
+	var updated any
+	if err := json.Unmarshal([]byte(data), &updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
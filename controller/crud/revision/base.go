@@ -0,0 +1,24 @@
+package revision
+
+import (
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/extension"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/xds"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+)
+
+type AppHandler struct {
+	Context   *db.AppContext
+	XDS       *xds.AppHandler
+	Extension *extension.AppHandler
+	Logger    *logger.Logger
+}
+
+func NewRevisionHandler(context *db.AppContext, xdsHandler *xds.AppHandler, extensionHandler *extension.AppHandler) *AppHandler {
+	return &AppHandler{
+		Context:   context,
+		XDS:       xdsHandler,
+		Extension: extensionHandler,
+		Logger:    logger.NewLogger("controller/revision"),
+	}
+}
@@ -0,0 +1,171 @@
+package revision
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
+)
+
+type RevisionDiff struct {
+	From  revisions.Revision         `json:"from"`
+	To    *revisions.Revision        `json:"to,omitempty"`
+	Live  bool                       `json:"live"`
+	Patch []revisions.PatchOperation `json:"patch"`
+}
+
+func (rh *AppHandler) ListRevisions(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+	return revisions.List(ctx, rh.Context.Client, revisions.ListFilter{
+		Project:    requestDetails.Project,
+		Collection: requestDetails.Collection,
+		Name:       requestDetails.Name,
+		Version:    requestDetails.Version,
+		Access:     accessFilter(requestDetails),
+	})
+}
+
+func (rh *AppHandler) GetRevision(ctx context.Context, requestDetails models.RequestDetails, revisionID string) (any, error) {
+	return revisions.Get(ctx, rh.Context.Client, revisionID, requestDetails.Project, accessFilter(requestDetails))
+}
+
+// DiffRevisions returns the json patch from one revision to another, or to the
+// live resource when no target revision is given.
+func (rh *AppHandler) DiffRevisions(ctx context.Context, requestDetails models.RequestDetails, fromID, toID string) (any, error) {
+	from, err := revisions.Get(ctx, rh.Context.Client, fromID, requestDetails.Project, accessFilter(requestDetails))
+	if err != nil {
+		return nil, err
+	}
+
+	diff := RevisionDiff{From: withoutBody(*from)}
+	var target any
+
+	if toID != "" {
+		to, err := revisions.Get(ctx, rh.Context.Client, toID, requestDetails.Project, accessFilter(requestDetails))
+		if err != nil {
+			return nil, err
+		}
+		summary := withoutBody(*to)
+		diff.To = &summary
+		target = to.Document()
+	} else {
+		live, err := rh.loadLive(ctx, requestDetails, from)
+		if err != nil {
+			return nil, err
+		}
+		diff.Live = true
+		if live != nil {
+			target = map[string]any{"general": live.General, "resource": live.Resource.Resource}
+		}
+	}
+
+	diff.Patch, err = revisions.Diff(from.Document(), target)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// Rollback writes the body of a revision back through the regular update path, or
+// the create path when the resource was deleted since, so it is validated and published.
+func (rh *AppHandler) Rollback(ctx context.Context, requestDetails models.RequestDetails, revisionID string) (any, error) {
+	revision, err := revisions.Get(ctx, rh.Context.Client, revisionID, requestDetails.Project, accessFilter(requestDetails))
+	if err != nil {
+		return nil, err
+	}
+
+	if revision.General == nil || revision.Resource == nil {
+		return nil, errors.New("revision has no resource body")
+	}
+
+	live, err := rh.loadLive(ctx, requestDetails, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	general := *revision.General
+	resource := &models.DBResource{
+		General:  general,
+		Resource: models.Resource{Resource: revision.Resource},
+	}
+
+	details := requestDetails
+	details.Name = general.Name
+	details.Collection = revision.Collection
+	details.Version = general.Version
+	details.GType = general.GType
+	details.CanonicalName = general.CanonicalName
+	if details.SaveOrPublish == "" {
+		details.SaveOrPublish = models.SavePublish
+	}
+
+	isExtension := helper.Contains([]string{"filters", "extensions"}, revision.Collection)
+
+	if live == nil {
+		resource.Resource.Version = "1"
+		if isExtension {
+			return rh.Extension.SetExtension(ctx, resource, details)
+		}
+		return rh.XDS.SetResource(ctx, resource, details)
+	}
+
+	resource.ID = live.ID
+	resource.Resource.Version = live.Resource.Version
+	details.ResourceID = live.ID.Hex()
+
+	if isExtension {
+		return rh.Extension.UpdateExtensions(ctx, resource, details)
+	}
+	return rh.XDS.UpdateResource(ctx, resource, details)
+}
+
+func (rh *AppHandler) loadLive(ctx context.Context, requestDetails models.RequestDetails, revision *revisions.Revision) (*models.DBResource, error) {
+	details := requestDetails
+	details.Collection = revision.Collection
+	filter := common.AddUserFilter(details, bson.M{
+		"general.name":    revision.Name,
+		"general.version": revision.Version,
+	})
+
+	live, err := revisions.LoadResource(ctx, rh.Context.Client, revision.Collection, filter)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return live, nil
+}
+
+// accessFilter narrows revisions to those the user can read, by the permissions and
+// labels the resource had when the revision was taken.
+func accessFilter(requestDetails models.RequestDetails) bson.M {
+	if requestDetails.User.IsOwner || requestDetails.User.Role == models.RoleAdmin {
+		return nil
+	}
+
+	collections := models.XDSCollections()
+	if requestDetails.Collection != "" {
+		collections = []string{requestDetails.Collection}
+	}
+
+	scopes := make([]bson.M, 0, len(collections))
+	for _, collection := range collections {
+		details := requestDetails
+		details.Collection = collection
+		scopes = append(scopes, common.AddUserFilter(details, bson.M{"collection": collection}))
+	}
+	return bson.M{"$or": scopes}
+}
+
+func withoutBody(revision revisions.Revision) revisions.Revision {
+	revision.General = nil
+	revision.Resource = nil
+	return revision
+}
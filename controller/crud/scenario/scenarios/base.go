@@ -189,6 +189,10 @@ var Resources = []ResourceTemplate{
 	}, */
 }
 
+// Order lists the template keys of the scenarios with every resource after those it
+// references, so the references of a resource exist when it is saved.
+var Order = []string{"endpoint", "cluster", "virtual_host", "route", "hcm", "tcp_proxy", "listener"}
+
 var Scenarios = map[Scenario]map[string]string{
 	"1": {
 		"cluster":  NonEdsCluster,
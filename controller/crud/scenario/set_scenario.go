@@ -31,8 +31,9 @@ func (sc *AppHandler) SetScenario(ctx context.Context, scenario models.ScenarioB
 	successfulResources := []models.ResourceClass{}
 	response := map[string]any{}
 
-	for key, templateStr := range templateMap {
-		if key != "managed" {
+	for _, key := range scenarios.Order {
+		templateStr, inScenario := templateMap[key]
+		if inScenario {
 			if data, ok := scenario[key]; ok {
 				templateData := map[string]any{
 					"Data":     data,
@@ -0,0 +1,23 @@
+package schemas
+
+import (
+	"sync"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+)
+
+type AppHandler struct {
+	Context *db.AppContext
+	Logger  *logger.Logger
+
+	openAPIOnce sync.Once
+	openAPI     map[string]any
+}
+
+func NewSchemaHandler(context *db.AppContext) *AppHandler {
+	return &AppHandler{
+		Context: context,
+		Logger:  logger.NewLogger("controller/schema"),
+	}
+}
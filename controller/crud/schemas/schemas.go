@@ -0,0 +1,36 @@
+package schemas
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/schema"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/version"
+)
+
+// GType returns the JSON Schema of the resource of a gtype. The schema is generated from
+// the proto descriptors the controller is built with, which are the same for every
+// configured Envoy version.
+func (sh *AppHandler) GType(requestDetails models.RequestDetails) (map[string]any, error) {
+	switch {
+	case requestDetails.GType == "":
+		return nil, errors.New("gtype is required")
+	case requestDetails.GType.ProtoMessage() == nil:
+		return nil, fmt.Errorf("unknown gtype: %s", requestDetails.GType)
+	}
+
+	document := schema.ForResource(requestDetails.GType)
+	document["title"] = requestDetails.GType.PrettyName()
+	document["x-elchi-gtype"] = requestDetails.GType.String()
+	return document, nil
+}
+
+// OpenAPI returns the OpenAPI document of the given routes and the operations of their
+// handlers. Routes do not change after startup, so the document is generated once.
+func (sh *AppHandler) OpenAPI(routes []schema.Route, operations map[string]schema.Operation) map[string]any {
+	sh.openAPIOnce.Do(func() {
+		sh.openAPI = schema.OpenAPI(routes, operations, version.GetVersion())
+	})
+	return sh.openAPI
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+// Predicate operators. A predicate holds when any value at its path satisfies it,
+// except for ne which holds when none of them equals the value.
+const (
+	OpEq       = "eq"
+	OpNe       = "ne"
+	OpGt       = "gt"
+	OpGte      = "gte"
+	OpLt       = "lt"
+	OpLte      = "lte"
+	OpMatch    = "match"
+	OpRegex    = "regex"
+	OpContains = "contains"
+	OpExists   = "exists"
+)
+
+type AppHandler struct {
+	Context *db.AppContext
+	Logger  *logger.Logger
+}
+
+func NewSearchHandler(context *db.AppContext) *AppHandler {
+	return &AppHandler{
+		Context: context,
+		Logger:  logger.NewLogger("controller/search"),
+	}
+}
+
+// Predicate tests the values found at a dotted path of the resource body. A * (or #)
+// segment walks every list item or object key. Paths starting with general. are read
+// from the general section instead of the body.
+type Predicate struct {
+	Path  string `json:"path"`
+	Op    string `json:"op"`
+	Value any    `json:"value"`
+}
+
+// Query selects resources matching every predicate and, when set, the free text and
+// the label selector. Without collections every xDS collection is searched.
+type Query struct {
+	Collections []string      `json:"collections"`
+	GType       models.GTypes `json:"gtype"`
+	Where       []Predicate   `json:"where"`
+	Text        string        `json:"text"`
+	Selector    string        `json:"selector"`
+}
+
+type Hit struct {
+	Path    string `json:"path"`
+	Snippet string `json:"snippet"`
+}
+
+type Match struct {
+	Collection string        `json:"collection"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version"`
+	GType      models.GTypes `json:"gtype"`
+	Hits       []Hit         `json:"hits"`
+}
+
+type Page struct {
+	Items      []Match `json:"items"`
+	Total      int     `json:"total"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
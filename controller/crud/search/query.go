@@ -0,0 +1,300 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const snippetLength = 160
+
+type compiledPredicate struct {
+	Predicate
+	general  bool
+	segments []string
+	pattern  *regexp.Regexp
+}
+
+type found struct {
+	path  string
+	value any
+}
+
+func compile(predicates []Predicate) ([]compiledPredicate, error) {
+	compiled := make([]compiledPredicate, 0, len(predicates))
+	for _, predicate := range predicates {
+		path := strings.TrimPrefix(strings.TrimSpace(predicate.Path), "$.")
+		if path == "" {
+			return nil, fmt.Errorf("predicate path is required")
+		}
+
+		c := compiledPredicate{Predicate: predicate}
+		if rest, ok := strings.CutPrefix(path, "general."); ok {
+			c.general = true
+			path = rest
+		}
+		c.segments = strings.Split(path, ".")
+
+		switch predicate.Op {
+		case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpContains:
+			if predicate.Value == nil {
+				return nil, fmt.Errorf("%s on %s needs a value", predicate.Op, predicate.Path)
+			}
+		case OpExists:
+		case OpMatch, OpRegex:
+			value, ok := predicate.Value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s on %s needs a string value", predicate.Op, predicate.Path)
+			}
+			if predicate.Op == OpMatch {
+				value = globToRegex(value)
+			}
+			pattern, err := regexp.Compile(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for %s: %w", predicate.Path, err)
+			}
+			c.pattern = pattern
+		default:
+			return nil, fmt.Errorf("unknown operator %q on %s", predicate.Op, predicate.Path)
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// evaluate reports whether the predicate holds and where it matched.
+func (c compiledPredicate) evaluate(general map[string]any, body any) (bool, []Hit) {
+	root, prefix := body, ""
+	if c.general {
+		root, prefix = general, "general"
+	}
+	values := resolve(root, c.segments, prefix)
+
+	switch c.Op {
+	case OpExists:
+		want := true
+		if value, ok := c.Value.(bool); ok {
+			want = value
+		}
+		if (len(values) > 0) != want {
+			return false, nil
+		}
+		return true, hits(values)
+	case OpNe:
+		for _, v := range values {
+			if equal(v.value, c.Value) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	var matched []found
+	for _, v := range values {
+		if c.test(v.value) {
+			matched = append(matched, v)
+		}
+	}
+	return len(matched) > 0, hits(matched)
+}
+
+func (c compiledPredicate) test(value any) bool {
+	switch c.Op {
+	case OpEq:
+		return equal(value, c.Value)
+	case OpGt, OpGte, OpLt, OpLte:
+		left, ok := number(value)
+		if !ok {
+			return false
+		}
+		right, ok := number(c.Value)
+		if !ok {
+			return false
+		}
+		switch c.Op {
+		case OpGt:
+			return left > right
+		case OpGte:
+			return left >= right
+		case OpLt:
+			return left < right
+		default:
+			return left <= right
+		}
+	case OpMatch, OpRegex:
+		text, ok := value.(string)
+		return ok && c.pattern.MatchString(text)
+	case OpContains:
+		switch v := value.(type) {
+		case string:
+			return strings.Contains(v, fmt.Sprint(c.Value))
+		case []any:
+			for _, item := range v {
+				if equal(item, c.Value) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// resolve returns the values at the path, * and # expand every list item or object key.
+func resolve(value any, segments []string, path string) []found {
+	if len(segments) == 0 {
+		return []found{{path: path, value: value}}
+	}
+
+	segment, rest := segments[0], segments[1:]
+	switch v := value.(type) {
+	case map[string]any:
+		if segment == "*" || segment == "#" {
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			var all []found
+			for _, key := range keys {
+				all = append(all, resolve(v[key], rest, joinPath(path, key))...)
+			}
+			return all
+		}
+		child, ok := v[segment]
+		if !ok {
+			return nil
+		}
+		return resolve(child, rest, joinPath(path, segment))
+	case []any:
+		if segment == "*" || segment == "#" {
+			var all []found
+			for i, item := range v {
+				all = append(all, resolve(item, rest, joinPath(path, strconv.Itoa(i)))...)
+			}
+			return all
+		}
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(v) {
+			return nil
+		}
+		return resolve(v[index], rest, joinPath(path, segment))
+	}
+	return nil
+}
+
+// textHits looks for the text in the names and metadata of the resource, case insensitive.
+func textHits(general map[string]any, body any, text string) []Hit {
+	text = strings.ToLower(text)
+	var matched []found
+	for _, key := range []string{"name", "canonical_name"} {
+		if value, ok := general[key].(string); ok && strings.Contains(strings.ToLower(value), text) {
+			matched = append(matched, found{path: "general." + key, value: value})
+		}
+	}
+	collectText(general["metadata"], "general.metadata", text, true, &matched)
+	collectText(body, "", text, false, &matched)
+	return hits(matched)
+}
+
+func collectText(value any, path, text string, inMetadata bool, matched *[]found) {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := joinPath(path, key)
+			if s, ok := v[key].(string); ok && (inMetadata || key == "name") {
+				if strings.Contains(strings.ToLower(s), text) {
+					*matched = append(*matched, found{path: child, value: s})
+				}
+				continue
+			}
+			collectText(v[key], child, text, inMetadata || key == "metadata", matched)
+		}
+	case []any:
+		for i, item := range v {
+			child := joinPath(path, strconv.Itoa(i))
+			if s, ok := item.(string); ok && inMetadata {
+				if strings.Contains(strings.ToLower(s), text) {
+					*matched = append(*matched, found{path: child, value: s})
+				}
+				continue
+			}
+			collectText(item, child, text, inMetadata, matched)
+		}
+	}
+}
+
+func equal(value, expected any) bool {
+	left, lok := number(value)
+	right, rok := number(expected)
+	if lok && rok {
+		return left == right
+	}
+	return fmt.Sprint(value) == fmt.Sprint(expected)
+}
+
+// number reads JSON numbers, numeric strings (64 bit integers) and protobuf durations
+// such as "5s" or "0.250s", durations are compared in seconds.
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n, true
+		}
+		if d, err := time.ParseDuration(v); err == nil {
+			return d.Seconds(), true
+		}
+	}
+	return 0, false
+}
+
+func globToRegex(glob string) string {
+	quoted := regexp.QuoteMeta(glob)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return "^" + quoted + "$"
+}
+
+func hits(values []found) []Hit {
+	result := make([]Hit, 0, len(values))
+	for _, v := range values {
+		result = append(result, Hit{Path: v.path, Snippet: snippet(v.value)})
+	}
+	return result
+}
+
+func snippet(value any) string {
+	var text string
+	if s, ok := value.(string); ok {
+		text = s
+	} else {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		text = string(data)
+	}
+	if runes := []rune(text); len(runes) > snippetLength {
+		return string(runes[:snippetLength]) + "..."
+	}
+	return text
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,209 @@
+package search
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/labels"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+type document struct {
+	General  map[string]any `json:"general"`
+	Resource struct {
+		Resource any `json:"resource"`
+	} `json:"resource"`
+}
+
+// Search runs the query over the xDS collections of the project, or of one version
+// of it when the request has a version. Resources the user may not see are filtered
+// out by the same permission filter the list endpoints use. Matches are ordered by
+// collection and name, the cursor of a page continues after its last match.
+func (sh *AppHandler) Search(ctx context.Context, requestDetails models.RequestDetails, query Query, limit int, cursor string) (*Page, error) {
+	if requestDetails.Project == "" {
+		return nil, errors.New("project is required")
+	}
+
+	text := strings.TrimSpace(query.Text)
+	if len(query.Where) == 0 && text == "" && strings.TrimSpace(query.Selector) == "" {
+		return nil, errors.New("a predicate, a text or a selector is required")
+	}
+	if _, err := labels.Parse(query.Selector); err != nil {
+		return nil, err
+	}
+	requestDetails.Selector = query.Selector
+
+	predicates, err := compile(query.Where)
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := searchCollections(query.Collections)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	page := &Page{Items: []Match{}}
+	for _, collection := range collections {
+		if err := sh.searchCollection(ctx, collection, requestDetails, query.GType, predicates, text, offset, limit, page); err != nil {
+			return nil, err
+		}
+	}
+
+	if next := offset + len(page.Items); next < page.Total {
+		page.NextCursor = encodeCursor(next)
+	}
+	return page, nil
+}
+
+func (sh *AppHandler) searchCollection(ctx context.Context, collection string, requestDetails models.RequestDetails, gtype models.GTypes, predicates []compiledPredicate, text string, offset, limit int, page *Page) error {
+	filter := bson.M{"general.project": requestDetails.Project}
+	if requestDetails.Version != "" {
+		filter["general.version"] = requestDetails.Version
+	}
+	if gtype != "" {
+		filter["general.gtype"] = gtype
+	}
+	requestDetails.Collection = collection
+	filter, err := common.AddSelectorFilter(requestDetails, filter)
+	if err != nil {
+		return err
+	}
+	filter = common.AddUserFilter(requestDetails, filter)
+
+	opts := options.Find().
+		SetProjection(bson.M{"general": 1, "resource.resource": 1}).
+		SetSort(bson.D{{Key: "general.name", Value: 1}, {Key: "general.version", Value: 1}})
+
+	cursor, err := sh.Context.Client.Collection(collection).Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("could not search %s: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var raw bson.M
+		if err := cursor.Decode(&raw); err != nil {
+			sh.Logger.Debugf("Decode fail: %v", err)
+			continue
+		}
+
+		doc, err := decodeDocument(raw)
+		if err != nil {
+			sh.Logger.Debugf("Convert fail: %v", err)
+			continue
+		}
+
+		hits, ok := evaluate(doc, predicates, text)
+		if !ok {
+			continue
+		}
+
+		if page.Total >= offset && len(page.Items) < limit {
+			name, _ := doc.General["name"].(string)
+			version, _ := doc.General["version"].(string)
+			gtype, _ := doc.General["gtype"].(string)
+			page.Items = append(page.Items, Match{
+				Collection: collection,
+				Name:       name,
+				Version:    version,
+				GType:      models.GTypes(gtype),
+				Hits:       hits,
+			})
+		}
+		page.Total++
+	}
+
+	return cursor.Err()
+}
+
+// evaluate requires every predicate and the text to match and collects their hits.
+func evaluate(doc *document, predicates []compiledPredicate, text string) ([]Hit, bool) {
+	all := []Hit{}
+	for _, predicate := range predicates {
+		ok, hits := predicate.evaluate(doc.General, doc.Resource.Resource)
+		if !ok {
+			return nil, false
+		}
+		all = append(all, hits...)
+	}
+
+	if text != "" {
+		hits := textHits(doc.General, doc.Resource.Resource, text)
+		if len(hits) == 0 {
+			return nil, false
+		}
+		all = append(all, hits...)
+	}
+	return all, true
+}
+
+func searchCollections(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return models.XDSCollections(), nil
+	}
+
+	collections := make([]string, 0, len(requested))
+	for _, collection := range models.XDSCollections() {
+		if helper.Contains(requested, collection) {
+			collections = append(collections, collection)
+		}
+	}
+	for _, collection := range requested {
+		if !helper.Contains(collections, collection) {
+			return nil, fmt.Errorf("unknown collection: %s", collection)
+		}
+	}
+	return collections, nil
+}
+
+func decodeDocument(raw bson.M) (*document, error) {
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc document
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errors.New("invalid cursor")
+	}
+	offset, err := strconv.Atoi(string(data))
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid cursor")
+	}
+	return offset, nil
+}
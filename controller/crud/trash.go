@@ -0,0 +1,72 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/trash"
+)
+
+// ListenerRecord is a record deleted together with a listener and the filter which
+// finds it.
+type ListenerRecord struct {
+	Collection string
+	Filter     bson.M
+}
+
+func ListenerRecords(name, project string) []ListenerRecord {
+	return []ListenerRecord{
+		{Collection: "bootstrap", Filter: bson.M{"general.name": name, "general.project": project}},
+		{Collection: "services", Filter: bson.M{"name": name, "project": project}},
+		{Collection: "admin_ports", Filter: bson.M{"name": name, "project": project}},
+	}
+}
+
+// CheckPermanentDelete only lets owners skip the trash.
+func CheckPermanentDelete(requestDetails models.RequestDetails) error {
+	if requestDetails.Permanent == "true" && !requestDetails.User.IsOwner {
+		return errors.New("only owners can delete resources permanently")
+	}
+	return nil
+}
+
+// MoveToTrash keeps the document matched by filter, and for a listener its bootstrap,
+// service and admin port, in the trash before they are deleted. Nothing is kept for
+// a permanent delete.
+func MoveToTrash(ctx context.Context, context *db.AppContext, requestDetails models.RequestDetails, collection string, filter bson.M) error {
+	if requestDetails.Permanent == "true" {
+		return nil
+	}
+
+	document, err := trash.LoadDocument(ctx, context.Client, collection, filter)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return errstr.ErrNoDocumentsDelete
+		}
+		return errstr.ErrUnknownDBError
+	}
+
+	var related []trash.Related
+	if collection == "listeners" {
+		for _, record := range ListenerRecords(requestDetails.Name, requestDetails.Project) {
+			relatedDocument, err := trash.LoadDocument(ctx, context.Client, record.Collection, record.Filter)
+			if err != nil {
+				if errors.Is(err, mongo.ErrNoDocuments) {
+					continue
+				}
+				return fmt.Errorf("could not read %s of listener %s: %w", record.Collection, requestDetails.Name, err)
+			}
+			related = append(related, trash.Related{Collection: record.Collection, Name: requestDetails.Name, Document: relatedDocument})
+		}
+	}
+
+	_, err = trash.Put(ctx, context.Client, collection, document, related, requestDetails.User)
+	return err
+}
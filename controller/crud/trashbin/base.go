@@ -0,0 +1,34 @@
+package trashbin
+
+import (
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/xds"
+	"github.com/CloudNativeWorks/elchi-backend/controller/poker"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+)
+
+type AppHandler struct {
+	Context *db.AppContext
+	XDS     *xds.AppHandler
+	Logger  *logger.Logger
+}
+
+func NewTrashHandler(context *db.AppContext, xdsHandler *xds.AppHandler) *AppHandler {
+	return &AppHandler{
+		Context: context,
+		XDS:     xdsHandler,
+		Logger:  logger.NewLogger("controller/trash"),
+	}
+}
+
+// Restored is a record written back from the trash.
+type Restored struct {
+	Collection string `json:"collection"`
+	Name       string `json:"name"`
+}
+
+type Result struct {
+	Restored  []Restored       `json:"restored"`
+	Warnings  []string         `json:"warnings,omitempty"`
+	Published *poker.Processed `json:"published,omitempty"`
+}
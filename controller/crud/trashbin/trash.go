@@ -0,0 +1,232 @@
+package trashbin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/trash"
+)
+
+const adminPortPath = "resource.resource.admin.address.socket_address.port_value"
+
+// List returns the deleted resources of the project the user may see.
+func (th *AppHandler) List(ctx context.Context, requestDetails models.RequestDetails) ([]trash.Entry, error) {
+	listFilter := trash.ListFilter{
+		Project:    requestDetails.Project,
+		Collection: requestDetails.Collection,
+		Name:       requestDetails.Name,
+		Version:    requestDetails.Version,
+	}
+	return trash.List(ctx, th.Context.Client, listFilter, common.AddUserFilter(requestDetails, bson.M{}))
+}
+
+// Restore writes a deleted resource back under its name in one transaction, a listener
+// together with its bootstrap, service and admin port. The admin port gets a new
+// number when another listener took it meanwhile. The affected listeners are poked
+// once the restore is committed.
+func (th *AppHandler) Restore(ctx context.Context, requestDetails models.RequestDetails, id string) (*Result, error) {
+	if requestDetails.Project == "" {
+		return nil, errors.New("project is required")
+	}
+
+	entry, err := trash.Get(ctx, th.Context.Client, id, requestDetails.Project, common.AddUserFilter(requestDetails, bson.M{}))
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := th.Context.Client.Client().StartSession()
+	if err != nil {
+		return nil, fmt.Errorf("could not start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	result := &Result{}
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		result.Restored = []Restored{}
+		result.Warnings = nil
+
+		if err := th.insert(sc, entry.Collection, entry.Name, entry.Document); err != nil {
+			return nil, err
+		}
+		result.Restored = append(result.Restored, Restored{Collection: entry.Collection, Name: entry.Name})
+
+		adminPort := 0
+		for _, related := range entry.Related {
+			if related.Collection == "admin_ports" {
+				port, err := th.restoreAdminPort(sc, entry, related)
+				if err != nil {
+					return nil, err
+				}
+				adminPort = port
+			} else if err := th.insert(sc, related.Collection, related.Name, related.Document); err != nil {
+				return nil, err
+			}
+			result.Restored = append(result.Restored, Restored{Collection: related.Collection, Name: related.Name})
+		}
+
+		if adminPort != 0 {
+			if err := th.moveAdminPort(sc, entry, adminPort); err != nil {
+				return nil, err
+			}
+			result.Warnings = append(result.Warnings, fmt.Sprintf("the admin port of listener %s was taken, it now uses %d", entry.Name, adminPort))
+		}
+
+		return nil, trash.Delete(sc, th.Context.Client, entry.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := th.afterRestore(ctx, entry, requestDetails.User, result)
+	if err != nil {
+		th.Logger.Errorf("Could not load restored %s/%s: %v", entry.Collection, entry.Name, err)
+		return result, nil
+	}
+	result.Published = crud.PublishChanges(ctx, th.Context, []models.ResourceClass{restored}, th.XDS.PokeService)
+	return result, nil
+}
+
+func (th *AppHandler) insert(ctx context.Context, collection, name string, document bson.M) error {
+	if _, err := th.Context.Client.Collection(collection).InsertOne(ctx, document); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("%s/%s exists again, delete or rename it before restoring", collection, name)
+		}
+		return fmt.Errorf("could not restore %s/%s: %w", collection, name, err)
+	}
+	return nil
+}
+
+// restoreAdminPort writes the admin port record back and returns 0, or allocates a
+// new port and returns it when another listener uses the old one.
+func (th *AppHandler) restoreAdminPort(ctx context.Context, entry *trash.Entry, related trash.Related) (int, error) {
+	collection := th.Context.Client.Collection("admin_ports")
+	taken, err := collection.CountDocuments(ctx, bson.M{"port": related.Document["port"]})
+	if err != nil {
+		return 0, fmt.Errorf("could not check admin port of listener %s: %w", entry.Name, err)
+	}
+	if taken == 0 {
+		return 0, th.insert(ctx, "admin_ports", related.Name, related.Document)
+	}
+
+	if err := collection.FindOne(ctx, bson.M{"name": related.Name, "project": entry.Project}).Err(); err == nil {
+		return 0, fmt.Errorf("admin_ports/%s exists again, delete it before restoring", related.Name)
+	}
+	return crud.GetNextAdminPort(ctx, th.Context.Client, related.Name, entry.Project)
+}
+
+func (th *AppHandler) moveAdminPort(ctx context.Context, entry *trash.Entry, port int) error {
+	bootstrapFilter := bson.M{"general.name": entry.Name, "general.project": entry.Project}
+	if _, err := th.Context.Client.Collection("bootstrap").UpdateOne(ctx, bootstrapFilter, bson.M{"$set": bson.M{adminPortPath: port}}); err != nil {
+		return fmt.Errorf("could not update admin port of bootstrap %s: %w", entry.Name, err)
+	}
+
+	serviceFilter := bson.M{"name": entry.Name, "project": entry.Project}
+	if _, err := th.Context.Client.Collection("services").UpdateOne(ctx, serviceFilter, bson.M{"$set": bson.M{"admin_port": port}}); err != nil {
+		return fmt.Errorf("could not update admin port of service %s: %w", entry.Name, err)
+	}
+	return nil
+}
+
+// afterRestore indexes the references of the restored resources, records them as
+// created and reports references which do not resolve anymore.
+func (th *AppHandler) afterRestore(ctx context.Context, entry *trash.Entry, user models.UserDetails, result *Result) (*models.DBResource, error) {
+	filter := bson.M{"_id": entry.Document["_id"]}
+	restored, err := revisions.LoadResource(ctx, th.Context.Client, entry.Collection, filter)
+	if err != nil {
+		return nil, err
+	}
+	th.record(ctx, entry.Collection, restored, user)
+
+	dangling, err := resources.FindDanglingReferences(ctx, th.Context, restored, th.Logger.Logger)
+	if err != nil {
+		th.Logger.Errorf("Could not check references: %v", err)
+	}
+	for _, ref := range dangling {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s (%s) does not exist", ref.Path, ref.Name, ref.GType.PrettyName()))
+	}
+
+	for _, related := range entry.Related {
+		if related.Collection != "bootstrap" {
+			continue
+		}
+		bootstrap, err := revisions.LoadResource(ctx, th.Context.Client, "bootstrap", bson.M{"_id": related.Document["_id"]})
+		if err != nil {
+			th.Logger.Errorf("Could not load restored bootstrap %s: %v", related.Name, err)
+			continue
+		}
+		th.record(ctx, "bootstrap", bootstrap, user)
+	}
+	return restored, nil
+}
+
+func (th *AppHandler) record(ctx context.Context, collection string, resource *models.DBResource, user models.UserDetails) {
+	if err := resources.IndexReferences(ctx, th.Context, resource, th.Logger.Logger); err != nil {
+		th.Logger.Errorf("Could not index references: %v", err)
+	}
+	crud.RecordRevision(ctx, th.Context, revisions.OperationCreate, resource, user)
+
+	general := resource.GetGeneral()
+	th.Context.Invalidation.Publish(invalidation.Event{
+		Collection: collection,
+		Name:       general.Name,
+		Project:    general.Project,
+		Version:    general.Version,
+	})
+}
+
+// Purge deletes a trash entry for good, only owners may do so.
+func (th *AppHandler) Purge(ctx context.Context, requestDetails models.RequestDetails, id string) (any, error) {
+	if !requestDetails.User.IsOwner {
+		return nil, errors.New("only owners can delete resources permanently")
+	}
+
+	entry, err := trash.Get(ctx, th.Context.Client, id, requestDetails.Project, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := trash.Delete(ctx, th.Context.Client, entry.ID); err != nil {
+		return nil, err
+	}
+	return gin.H{"message": "Success"}, nil
+}
+
+// StartRetention purges the trash entries older than the configured retention at
+// every interval.
+func (th *AppHandler) StartRetention(interval time.Duration) {
+	retention := trash.DefaultRetention
+	if configured := th.Context.Config.ElchiTrashRetention; configured != "" {
+		parsed, err := time.ParseDuration(configured)
+		if err != nil || parsed <= 0 {
+			th.Logger.Warnf("Invalid trash retention %q, using %s", configured, retention)
+		} else {
+			retention = parsed
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			purged, err := trash.Purge(context.Background(), th.Context.Client, time.Now().Add(-retention))
+			if err != nil {
+				th.Logger.Errorf("%v", err)
+				continue
+			}
+			if purged > 0 {
+				th.Logger.Infof("Purged %d trash entries older than %s", purged, retention)
+			}
+		}
+	}()
+}
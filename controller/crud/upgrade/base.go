@@ -0,0 +1,60 @@
+package upgrade
+
+import (
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/bundle"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+)
+
+type AppHandler struct {
+	Context *db.AppContext
+	Bundle  *bundle.AppHandler
+	Logger  *logger.Logger
+}
+
+func NewUpgradeHandler(context *db.AppContext, bundleHandler *bundle.AppHandler) *AppHandler {
+	return &AppHandler{
+		Context: context,
+		Bundle:  bundleHandler,
+		Logger:  logger.NewLogger("controller/upgrade"),
+	}
+}
+
+type Options struct {
+	From          string
+	To            string
+	OnConflict    string
+	SaveOrPublish string
+}
+
+// ResourceReport is what the upgrade does to one resource and what the target
+// version thinks of the result.
+type ResourceReport struct {
+	Collection string        `json:"collection"`
+	Name       string        `json:"name"`
+	GType      models.GTypes `json:"gtype"`
+	Migrations []string      `json:"migrations,omitempty"`
+	resources.FieldReport
+	Error string `json:"error,omitempty"`
+}
+
+// ServiceReport is the service a managed listener runs as in the target version. The
+// service of a listener is shared by its versions, an existing one keeps its admin port
+// and clients.
+type ServiceReport struct {
+	Name      string `json:"name"`
+	AdminPort uint32 `json:"admin_port,omitempty"`
+	Existing  bool   `json:"existing"`
+}
+
+type Plan struct {
+	Project   string               `json:"project"`
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	Ready     bool                 `json:"ready"`
+	Resources []ResourceReport     `json:"resources"`
+	Services  []ServiceReport      `json:"services,omitempty"`
+	Import    *bundle.ImportResult `json:"import,omitempty"`
+}
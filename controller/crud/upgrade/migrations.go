@@ -0,0 +1,122 @@
+package upgrade
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+// fieldMigration moves a field envoy deprecated to its replacement. Both fields
+// exist in every supported version, so a migrated resource stays valid in the old one.
+type fieldMigration struct {
+	GType models.GTypes
+	// Parent is the dotted path of the object holding the field, # walks every list item.
+	Parent string
+	From   string
+	To     string
+}
+
+var fieldMigrations = []fieldMigration{
+	{GType: models.Listener, Parent: "#", From: "reuse_port", To: "enable_reuse_port"},
+	{GType: models.Cluster, Parent: "", From: "track_timeout_budgets", To: "track_cluster_stats.timeout_budgets"},
+	{GType: models.Route, Parent: "virtual_hosts.#.routes.#.route", From: "max_grpc_timeout", To: "max_stream_duration.grpc_timeout_header_max"},
+	{GType: models.Route, Parent: "virtual_hosts.#.routes.#.route", From: "grpc_timeout_offset", To: "max_stream_duration.grpc_timeout_header_offset"},
+	{GType: models.VirtualHost, Parent: "routes.#.route", From: "max_grpc_timeout", To: "max_stream_duration.grpc_timeout_header_max"},
+	{GType: models.VirtualHost, Parent: "routes.#.route", From: "grpc_timeout_offset", To: "max_stream_duration.grpc_timeout_header_offset"},
+	{GType: models.HTTPConnectionManager, Parent: "route_config.virtual_hosts.#.routes.#.route", From: "max_grpc_timeout", To: "max_stream_duration.grpc_timeout_header_max"},
+	{GType: models.HTTPConnectionManager, Parent: "route_config.virtual_hosts.#.routes.#.route", From: "grpc_timeout_offset", To: "max_stream_duration.grpc_timeout_header_offset"},
+}
+
+// migrate applies the known migrations of the gtype in place and describes each move.
+// A field is left alone when its replacement is already set.
+func migrate(gtype models.GTypes, body any) []string {
+	var applied []string
+	for _, migration := range fieldMigrations {
+		if migration.GType != gtype {
+			continue
+		}
+		for _, parent := range resolveObjects(body, splitPath(migration.Parent), "") {
+			value, ok := parent.object[migration.From]
+			if !ok {
+				continue
+			}
+			to := strings.Split(migration.To, ".")
+			if !setIfMissing(parent.object, to, value) {
+				continue
+			}
+			delete(parent.object, migration.From)
+			applied = append(applied, fmt.Sprintf("%s -> %s", joinPath(parent.path, migration.From), joinPath(parent.path, migration.To)))
+		}
+	}
+	return applied
+}
+
+type resolvedObject struct {
+	path   string
+	object map[string]any
+}
+
+func resolveObjects(value any, segments []string, path string) []resolvedObject {
+	if len(segments) == 0 {
+		if object, ok := value.(map[string]any); ok {
+			return []resolvedObject{{path: path, object: object}}
+		}
+		return nil
+	}
+
+	segment, rest := segments[0], segments[1:]
+	if segment == "#" {
+		items, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+		var resolved []resolvedObject
+		for i, item := range items {
+			resolved = append(resolved, resolveObjects(item, rest, joinPath(path, fmt.Sprint(i)))...)
+		}
+		return resolved
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	return resolveObjects(object[segment], rest, joinPath(path, segment))
+}
+
+func setIfMissing(object map[string]any, path []string, value any) bool {
+	for _, key := range path[:len(path)-1] {
+		child, exists := object[key]
+		if !exists {
+			child = map[string]any{}
+			object[key] = child
+		}
+		next, ok := child.(map[string]any)
+		if !ok {
+			return false
+		}
+		object = next
+	}
+
+	last := path[len(path)-1]
+	if _, exists := object[last]; exists {
+		return false
+	}
+	object[last] = value
+	return true
+}
+
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,137 @@
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/bundle"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+)
+
+// Upgrade copies the live resources of a project from one envoy version to another.
+// Each resource is migrated and validated against the target version before anything
+// is written, the copy itself is a bundle import into the target version. With preview
+// set only the plan is returned.
+func (uh *AppHandler) Upgrade(ctx context.Context, requestDetails models.RequestDetails, upgradeOptions Options, preview bool) (*Plan, error) {
+	if err := uh.checkVersions(upgradeOptions); err != nil {
+		return nil, err
+	}
+
+	source := requestDetails
+	source.Version = upgradeOptions.From
+	// The copy stays in this installation, so secrets keep their keys.
+	exported, err := uh.Bundle.Export(ctx, source, bundle.ExportOptions{IncludeSecretKeys: true})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		Project:   requestDetails.Project,
+		From:      upgradeOptions.From,
+		To:        upgradeOptions.To,
+		Ready:     true,
+		Resources: make([]ResourceReport, 0, len(exported.Resources)),
+	}
+
+	invalid := 0
+	for i := range exported.Resources {
+		report := uh.prepare(ctx, &exported.Resources[i], requestDetails.Project, upgradeOptions.To)
+		if report.Error != "" {
+			invalid++
+		}
+		plan.Resources = append(plan.Resources, report)
+	}
+	plan.Ready = invalid == 0
+
+	plan.Services, err = uh.services(ctx, exported.Resources, requestDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	target := requestDetails
+	target.Version = upgradeOptions.To
+	exported.Version = upgradeOptions.To
+
+	dryRun := preview || !plan.Ready
+	result, err := uh.Bundle.Import(ctx, target, exported, bundle.ImportOptions{
+		OnConflict:    upgradeOptions.OnConflict,
+		SaveOrPublish: upgradeOptions.SaveOrPublish,
+	}, dryRun)
+	plan.Import = result
+	if err != nil {
+		if result != nil && !dryRun {
+			return nil, fmt.Errorf("upgrade failed, nothing was written: %w", err)
+		}
+		return nil, err
+	}
+
+	if !preview && !plan.Ready {
+		return nil, fmt.Errorf("%d resources fail validation against version %s, nothing was written", invalid, upgradeOptions.To)
+	}
+	return plan, nil
+}
+
+// prepare migrates the resource in place and checks it against the target version.
+func (uh *AppHandler) prepare(ctx context.Context, resource *bundle.Resource, project, version string) ResourceReport {
+	gtype := resource.General.GType
+	report := ResourceReport{
+		Collection: resource.Collection,
+		Name:       resource.General.Name,
+		GType:      gtype,
+		Migrations: migrate(gtype, resource.Resource),
+	}
+
+	report.FieldReport = resources.InspectFields(gtype, resource.Resource)
+
+	nodeid := fmt.Sprintf("%s::%s", resource.General.Name, project)
+	if err := resources.ValidateResourceWithClient(ctx, gtype, version, nodeid, resource.Resource, uh.Bundle.XDS.ResourceService); err != nil {
+		report.Error = err.Error()
+	}
+	return report
+}
+
+// services reports the service of each managed listener, the listeners of the target
+// version reuse the services of the source version.
+func (uh *AppHandler) services(ctx context.Context, exported []bundle.Resource, project string) ([]ServiceReport, error) {
+	var reports []ServiceReport
+	collection := uh.Context.Client.Collection("services")
+	for _, resource := range exported {
+		if resource.Collection != "listeners" || !resource.General.Managed {
+			continue
+		}
+
+		report := ServiceReport{Name: resource.General.Name}
+		var service models.Service
+		err := collection.FindOne(ctx, bson.M{"name": resource.General.Name, "project": project}).Decode(&service)
+		switch {
+		case err == nil:
+			report.Existing = true
+			report.AdminPort = service.AdminPort
+		case !errors.Is(err, mongo.ErrNoDocuments):
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (uh *AppHandler) checkVersions(upgradeOptions Options) error {
+	if upgradeOptions.From == "" || upgradeOptions.To == "" {
+		return errors.New("from and to versions are required")
+	}
+	if upgradeOptions.From == upgradeOptions.To {
+		return errors.New("from and to versions are the same")
+	}
+	for _, version := range []string{upgradeOptions.From, upgradeOptions.To} {
+		if !helper.Contains(uh.Context.Config.ElchiVersions, version) {
+			return fmt.Errorf("version %s is not one of the configured versions", version)
+		}
+	}
+	return nil
+}
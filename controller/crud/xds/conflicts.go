@@ -0,0 +1,20 @@
+package xds
+
+import (
+	"context"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/services"
+)
+
+// checkListenerConflicts rejects a listener which binds an ip:port another listener
+// already takes on one of the clients of its service.
+func (xds *AppHandler) checkListenerConflicts(ctx context.Context, resource models.ResourceClass) error {
+	general := resource.GetGeneral()
+	if general.GType != models.Listener {
+		return nil
+	}
+
+	clients := services.FetchDownstreamAddressFromService(xds.Context.Client, general.Name, general.Project, general.Version)
+	return services.CheckListenerConflicts(ctx, xds.Context.Client, general.Name, general.Project, general.Version, resource.GetResource(), clients)
+}
@@ -7,19 +7,27 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/drafts"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models/downstreamfilters"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
 )
 
 func (xds *AppHandler) DelResource(ctx context.Context, _ models.ResourceClass, requestDetails models.RequestDetails) (any, error) {
 	resourceType := requestDetails.Collection
 	collection := xds.Context.Client.Collection(resourceType)
 
+	if err := crud.CheckPermanentDelete(requestDetails); err != nil {
+		return nil, err
+	}
+
 	isDefault, err := common.IsDefaultResource(ctx, xds.Context, requestDetails.Name, resourceType, requestDetails.Project)
 	if err != nil {
 		xds.Logger.Errorf("An error occurred while checking if the resource is default: %v", err)
@@ -48,37 +56,91 @@ func (xds *AppHandler) DelResource(ctx context.Context, _ models.ResourceClass,
 		return nil, err
 	}
 
-	if err := deleteDocument(ctx, collection, filter); err != nil {
+	deleted, err := revisions.LoadResource(ctx, xds.Context.Client, resourceType, filter)
+	if err != nil {
+		xds.Logger.Errorf("Could not load resource before delete: %v", err)
+	}
+
+	var bootstrap *models.DBResource
+	err = crud.InTransaction(ctx, xds.Context, func(sc context.Context) error {
+		if err := crud.MoveToTrash(sc, xds.Context, requestDetails, resourceType, filter); err != nil {
+			return err
+		}
+		if err := deleteDocument(sc, collection, filter); err != nil {
+			return err
+		}
+		if resourceType != "listeners" {
+			return nil
+		}
+
+		var err error
+		if bootstrap, err = xds.delBootstrap(sc, requestDetails); err != nil {
+			return err
+		}
+		if err := xds.delService(sc, requestDetails); err != nil {
+			return err
+		}
+		return xds.delAdminPort(sc, requestDetails)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	if deleted != nil {
+		crud.RecordRevision(ctx, xds.Context, revisions.OperationDelete, deleted, requestDetails.User)
+	}
+
+	if err := resources.RemoveReferences(ctx, xds.Context, resourceType, requestDetails.Name, requestDetails.Project, requestDetails.Version); err != nil {
+		xds.Logger.Errorf("Could not remove references: %v", err)
+	}
+	if err := drafts.RemoveFor(ctx, xds.Context.Client, resourceType, requestDetails.Name, requestDetails.Project, requestDetails.Version); err != nil {
+		xds.Logger.Errorf("%v", err)
+	}
+
+	crud.Invalidate(ctx, xds.Context, invalidation.Event{
+		Collection: resourceType,
+		Name:       requestDetails.Name,
+		Project:    requestDetails.Project,
+		Version:    requestDetails.Version,
+	})
+
 	if resourceType == "listeners" {
-		if err := xds.delBootstrap(ctx, filter); err != nil {
-			return nil, err
+		if bootstrap != nil {
+			crud.RecordRevision(ctx, xds.Context, revisions.OperationDelete, bootstrap, requestDetails.User)
 		}
-		if err := xds.delService(ctx, requestDetails); err != nil {
-			return nil, err
+		if err := resources.RemoveReferences(ctx, xds.Context, "bootstrap", requestDetails.Name, requestDetails.Project, ""); err != nil {
+			xds.Logger.Errorf("Could not remove bootstrap references: %v", err)
 		}
-		if err := xds.delAdminPort(ctx, requestDetails); err != nil {
-			return nil, err
+		if err := drafts.RemoveFor(ctx, xds.Context.Client, "bootstrap", requestDetails.Name, requestDetails.Project, ""); err != nil {
+			xds.Logger.Errorf("%v", err)
 		}
+		crud.Invalidate(ctx, xds.Context, invalidation.Event{
+			Collection: "bootstrap",
+			Name:       requestDetails.Name,
+			Project:    requestDetails.Project,
+		})
 	}
 
 	return gin.H{"message": "Success"}, nil
 }
 
-func (xds *AppHandler) delBootstrap(ctx context.Context, filter primitive.M) error {
+// delBootstrap deletes the bootstrap of a listener and returns it for the revision history.
+func (xds *AppHandler) delBootstrap(ctx context.Context, requestDetails models.RequestDetails) (*models.DBResource, error) {
 	collection := xds.Context.Client.Collection("bootstrap")
-	delete(filter, "_id")
+	filter := bson.M{"general.name": requestDetails.Name, "general.project": requestDetails.Project}
 	if err := checkDocumentExists(ctx, collection, filter); err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := deleteDocument(ctx, collection, filter); err != nil {
-		return err
+	deleted, err := revisions.LoadResource(ctx, xds.Context.Client, "bootstrap", filter)
+	if err != nil {
+		xds.Logger.Errorf("Could not load bootstrap before delete: %v", err)
 	}
 
-	return nil
+	if err := deleteDocument(ctx, collection, filter); err != nil {
+		return nil, err
+	}
+	return deleted, nil
 }
 
 func (xds *AppHandler) delService(ctx context.Context, requestDetails models.RequestDetails) error {
@@ -4,14 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
 )
 
 func (xds *AppHandler) SetResource(ctx context.Context, resource models.ResourceClass, requestDetails models.RequestDetails) (any, error) {
@@ -20,46 +25,96 @@ func (xds *AppHandler) SetResource(ctx context.Context, resource models.Resource
 	if err != nil {
 		return nil, err
 	}
-	bootstrapID := ""
-	resourceID := ""
-	serviceID := ""
-	adminPort := uint32(0)
-	collection := xds.Context.Client.Collection(general.Collection)
-	inserResult, err := collection.InsertOne(ctx, resource)
+
+	warnings, err := resources.CheckReferences(ctx, xds.Context, resource, xds.Logger.Logger)
 	if err != nil {
-		if er := new(mongo.WriteException); errors.As(err, &er) && er.WriteErrors[0].Code == 11000 {
-			return nil, errstr.ErrNameAlreadyExists
-		}
 		return nil, err
 	}
 
-	if general.GType == models.Listener {
-		bootstrapID, adminPort, err = xds.createBootstrap(ctx, general, requestDetails)
+	findings, err := resources.CheckLint(ctx, xds.Context, resource, xds.Logger.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := xds.checkListenerConflicts(ctx, resource); err != nil {
+		return nil, err
+	}
+
+	bootstrapID := ""
+	resourceID := ""
+	serviceID := ""
+	var inserResult *mongo.InsertOneResult
+	err = crud.InTransaction(ctx, xds.Context, func(sc context.Context) error {
+		collection := xds.Context.Client.Collection(general.Collection)
+		var err error
+		inserResult, err = collection.InsertOne(sc, resource)
 		if err != nil {
-			return nil, err
+			if er := new(mongo.WriteException); errors.As(err, &er) && er.WriteErrors[0].Code == 11000 {
+				return errstr.ErrNameAlreadyExists
+			}
+			return err
+		}
+
+		if err := resources.IndexReferences(sc, xds.Context, resource, xds.Logger.Logger); err != nil {
+			return fmt.Errorf("could not index references: %w", err)
 		}
 
-		if general.Managed {
-			serviceID, err = xds.createService(ctx, general.Name, general.Project, adminPort)
+		if general.GType == models.Listener {
+			var adminPort uint32
+			bootstrapID, adminPort, err = xds.createBootstrap(sc, general, requestDetails)
 			if err != nil {
-				return nil, err
+				return err
+			}
+
+			if general.Managed {
+				serviceID, err = xds.createService(sc, general.Name, general.Project, adminPort)
+				if err != nil {
+					return err
+				}
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	crud.RecordRevision(ctx, xds.Context, revisions.OperationCreate, resource, requestDetails.User)
+
+	crud.Invalidate(ctx, xds.Context, invalidation.Event{
+		Collection: general.Collection,
+		Name:       general.Name,
+		Project:    general.Project,
+		Version:    general.Version,
+	})
 
 	if oid, ok := inserResult.InsertedID.(primitive.ObjectID); ok {
 		resource.SetID(oid)
 		resourceID = oid.Hex()
 	}
 
-	data := map[string]any{"bootstrap_id": bootstrapID, "resource_id": resourceID, "service_id": serviceID}
-
+	data := crud.WithWarnings(map[string]any{"bootstrap_id": bootstrapID, "resource_id": resourceID, "service_id": serviceID}, warnings, findings)
 	return map[string]any{"message": "Success", "data": data}, nil
 }
 
+// createService creates the service of a managed listener. A listener of the same name
+// in another version already has one, the service and its admin port are shared by
+// every version, so the existing one is returned.
 func (xds *AppHandler) createService(ctx context.Context, serviceName string, project string, adminPort uint32) (string, error) {
 	var service models.Service
 	collection := xds.Context.Client.Collection("services")
+
+	var existing struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"_id": 1})
+	err := collection.FindOne(ctx, bson.M{"name": serviceName, "project": project}, opts).Decode(&existing)
+	if err == nil {
+		return existing.ID.Hex(), nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return "", err
+	}
+
 	service.Name = serviceName
 	service.Project = project
 	service.AdminPort = adminPort
@@ -108,6 +163,11 @@ func (xds *AppHandler) createBootstrap(ctx context.Context, listenerGeneral mode
 		return "", 0, err
 	}
 
+	if err := resources.IndexReferences(ctx, xds.Context, resource, xds.Logger.Logger); err != nil {
+		return "", 0, fmt.Errorf("could not index bootstrap references: %w", err)
+	}
+	crud.RecordRevision(ctx, xds.Context, revisions.OperationCreate, resource, requestDetails.User)
+
 	if oid, ok := inserResult.InsertedID.(primitive.ObjectID); ok {
 		return oid.Hex(), adminPort, nil
 	}
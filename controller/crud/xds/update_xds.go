@@ -15,8 +15,10 @@ import (
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
 )
 
 func (xds *AppHandler) UpdateResource(ctx context.Context, resource models.ResourceClass, requestDetails models.RequestDetails) (any, error) {
@@ -44,8 +46,18 @@ func (xds *AppHandler) UpdateResource(ctx context.Context, resource models.Resou
 		return nil, errstr.ErrUnknownDBError
 	}
 
+	version, err := common.ExpectedVersion(resource, requestDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := xds.Context.Client.Collection(requestDetails.Collection)
+	versionedFilter := common.VersionedFilter(filterWithRestriction, version)
+	if err := collection.FindOne(ctx, versionedFilter).Err(); err != nil {
+		return nil, common.VersionConflict(ctx, collection, filterWithRestriction)
+	}
+
 	newResource := resource.GetResource()
-	version, _ := strconv.Atoi(resource.GetVersion().(string))
 	nodeid := fmt.Sprintf("%s::%s", requestDetails.Name, requestDetails.Project)
 
 	if err := resources.ValidateResourceWithClient(context.Background(), resource.GetGeneral().GType, resource.GetGeneral().Version, nodeid, newResource, xds.ResourceService); err != nil {
@@ -55,6 +67,28 @@ func (xds *AppHandler) UpdateResource(ctx context.Context, resource models.Resou
 	resource.SetVersion(strconv.Itoa(version + 1))
 	resource.SetTypedConfig(resources.DecodeSetTypedConfigs(resource, xds.Logger.Logger))
 
+	warnings, err := resources.CheckReferences(ctx, xds.Context, resource, xds.Logger.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := resources.CheckLint(ctx, xds.Context, resource, xds.Logger.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := xds.checkListenerConflicts(ctx, resource); err != nil {
+		return nil, err
+	}
+
+	if requestDetails.SaveOrPublish == models.SaveDraft {
+		draft, err := crud.SaveDraft(ctx, xds.Context, collection, versionedFilter, resource, version, requestDetails.User)
+		if err != nil {
+			return nil, err
+		}
+		return crud.WithWarnings(gin.H{"message": "Success", "data": gin.H{"draft": draft}}, warnings, findings), nil
+	}
+
 	update := bson.M{
 		"$set": bson.M{
 			"resource.resource":        newResource,
@@ -62,14 +96,36 @@ func (xds *AppHandler) UpdateResource(ctx context.Context, resource models.Resou
 			"general.config_discovery": resource.GetConfigDiscovery(),
 			"general.updated_at":       primitive.NewDateTimeFromTime(time.Now()),
 			"general.typed_config":     resource.GetTypedConfig(),
+			"general.updated_by":       requestDetails.User.UserName,
 		},
 	}
 
-	collection := xds.Context.Client.Collection(requestDetails.Collection)
-	_, err = collection.UpdateOne(ctx, filterWithRestriction, update)
+	err = crud.InTransaction(ctx, xds.Context, func(sc context.Context) error {
+		updateResult, err := collection.UpdateOne(sc, versionedFilter, update)
+		if err != nil {
+			return err
+		}
+
+		if updateResult.MatchedCount == 0 {
+			return common.VersionConflict(sc, collection, filterWithRestriction)
+		}
+
+		if err := resources.IndexReferences(sc, xds.Context, resource, xds.Logger.Logger); err != nil {
+			return fmt.Errorf("could not index references: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	crud.RecordRevision(ctx, xds.Context, revisions.OperationUpdate, resource, requestDetails.User)
+
+	crud.Invalidate(ctx, xds.Context, invalidation.Event{
+		Collection: requestDetails.Collection,
+		Name:       requestDetails.Name,
+		Project:    requestDetails.Project,
+		Version:    resource.GetGeneral().Version,
+	})
 
 	project := resource.GetGeneral().Project
 	changedResources := crud.HandleResourceChange(ctx, resource, requestDetails, xds.Context, project, xds.PokeService)
@@ -82,5 +138,5 @@ func (xds *AppHandler) UpdateResource(ctx context.Context, resource models.Resou
 		}
 	}
 
-	return gin.H{"message": "Success", "data": changedResources}, nil
+	return crud.WithWarnings(gin.H{"message": "Success", "data": changedResources}, warnings, findings), nil
 }
@@ -5,80 +5,111 @@ import (
 	"fmt"
 )
 
-var (
-	visitedUpstream   = make(map[string]bool)
-	visitedDownstream = make(map[string]bool)
-)
-
-func (h *AppHandler) ProcessResource(ctx context.Context, activeResource Depend, version string) {
-	h.SetVersion(version)
-	visitedUpstream = make(map[string]bool)
-	h.ProcessUpstream(ctx, activeResource)
-
-	visitedDownstream = make(map[string]bool)
-	h.ProcessDownstream(ctx, activeResource)
+func (t *traversal) ProcessResource(ctx context.Context, activeResource Depend) {
+	t.ProcessUpstream(ctx, activeResource)
+	t.ProcessDownstream(ctx, activeResource)
 }
 
 func generateUniqueKey(resource Depend) string {
 	return fmt.Sprintf("%s_%s_%s_%s", resource.Name, resource.Gtype, resource.Collection, resource.Project)
 }
 
-func (h *AppHandler) ProcessUpstream(ctx context.Context, activeResource Depend) {
-	uniqueKey := generateUniqueKey(activeResource)
-	if visitedUpstream[uniqueKey] {
-		return
-	}
+// ProcessUpstream walks the upstream side level by level, every level is fetched in parallel.
+func (t *traversal) ProcessUpstream(ctx context.Context, activeResource Depend) {
+	visited := map[string]bool{generateUniqueKey(activeResource): true}
+	level := []Depend{activeResource}
 
-	visitedUpstream[uniqueKey] = true
-	node, upstreams := h.CallUpstreamFunction(ctx, activeResource)
-	if node.ID != "" && node.Name != "" && node.Gtype != "" {
-		h.AddNode(node)
-		activeResource.First = false
-	} else {
-		h.Logger.Infof("Node is missing required fields, not adding: %+v\n", node)
-	}
+	for depth := 0; len(level) > 0; depth++ {
+		var next []Depend
+		for _, result := range t.collectLevel(ctx, level, t.upstreamCollector) {
+			node := result.node
+			if t.isNodeValid(node) {
+				t.AddNode(node)
+			} else {
+				t.handler.Logger.Infof("Node is missing required fields, not adding: %+v\n", node)
+			}
+
+			for _, up := range result.deps {
+				if up.ID == "" || up.Name == "" || up.Gtype == "" {
+					t.handler.Logger.Infof("Upstream is missing required fields, not adding: %+v\n", up)
+					continue
+				}
+
+				t.AddNodeAndEdge(node, up, true)
+				uniqueKey := generateUniqueKey(up)
+				if visited[uniqueKey] {
+					continue
+				}
+				visited[uniqueKey] = true
 
-	for _, up := range upstreams {
-		if up.ID != "" && up.Name != "" && up.Gtype != "" {
-			h.AddNodeAndEdge(node, up, true)
-			h.ProcessUpstream(ctx, up)
-		} else {
-			h.Logger.Infof("Upstream is missing required fields, not adding: %+v\n", up)
+				if depth+1 >= t.maxDepth {
+					t.handler.Logger.Debugf("Max dependency depth (%d) reached at %s, not expanding", t.maxDepth, up.Name)
+					t.AddNode(nodeFromDepend(up, "upstream"))
+					continue
+				}
+				next = append(next, up)
+			}
 		}
+		level = next
 	}
 }
 
-func (h *AppHandler) ProcessDownstream(ctx context.Context, activeResource Depend) {
-	uniqueKey := generateUniqueKey(activeResource)
-	if visitedDownstream[uniqueKey] {
-		return
-	}
+// ProcessDownstream walks the downstream side level by level, every level is fetched in parallel.
+func (t *traversal) ProcessDownstream(ctx context.Context, activeResource Depend) {
+	visited := map[string]bool{generateUniqueKey(activeResource): true}
+	level := []Depend{activeResource}
 
-	visitedDownstream[uniqueKey] = true
+	for depth := 0; len(level) > 0; depth++ {
+		var next []Depend
+		for _, result := range t.collectLevel(ctx, level, t.downstreamCollector) {
+			node := result.node
+			if t.isNodeValid(node) {
+				t.AddNode(node)
+			} else {
+				t.handler.Logger.Infof("Node is missing required fields, not adding: %+v\n", node)
+			}
 
-	node, downstreams := h.CallDownstreamFunction(ctx, activeResource)
-	if h.isNodeValid(node) {
-		h.AddNode(node)
-		activeResource.First = false
-	} else {
-		h.Logger.Infof("Node is missing required fields, not adding: %+v\n", node)
-	}
+			for _, down := range result.deps {
+				if !t.isValidDownstream(node, down) {
+					t.handler.Logger.Infof("Downstream is missing required fields, not directly connected, or from incorrect source, not adding: %+v\n", down)
+					continue
+				}
+
+				t.AddNodeAndEdge(node, down, false)
+				uniqueKey := generateUniqueKey(down)
+				if visited[uniqueKey] {
+					continue
+				}
+				visited[uniqueKey] = true
 
-	for _, down := range downstreams {
-		if h.isValidDownstream(node, down) {
-			h.AddNodeAndEdge(node, down, false)
-			h.ProcessDownstream(ctx, down)
-		} else {
-			h.Logger.Infof("Downstream is missing required fields, not directly connected, or from incorrect source, not adding: %+v\n", down)
+				if depth+1 >= t.maxDepth {
+					t.handler.Logger.Debugf("Max dependency depth (%d) reached at %s, not expanding", t.maxDepth, down.Name)
+					t.AddNode(nodeFromDepend(down, "downstream"))
+					continue
+				}
+				next = append(next, down)
+			}
 		}
+		level = next
 	}
 }
 
-func (h *AppHandler) isNodeValid(node Node) bool {
+func (t *traversal) isNodeValid(node Node) bool {
 	return node.ID != "" && node.Name != "" && node.Gtype != ""
 }
 
-func (h *AppHandler) isValidDownstream(node Node, down Depend) bool {
+func (t *traversal) isValidDownstream(node Node, down Depend) bool {
 	return down.ID != "" && down.Name != "" && down.Gtype != "" &&
 		down.Direction == "downstream" && down.Source == node.ID
 }
+
+func nodeFromDepend(dep Depend, direction string) Node {
+	return Node{
+		ID:         dep.ID,
+		Name:       dep.Name,
+		Gtype:      dep.Gtype,
+		Collection: dep.Gtype.CollectionString(),
+		Link:       dep.Gtype.URL(),
+		Direction:  direction,
+	}
+}
@@ -3,9 +3,11 @@ package dependency
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
 )
@@ -36,6 +38,36 @@ func (h *AppHandler) setCacheEntry(key string, entry CacheEntry) {
 	h.Cache[key] = entry
 }
 
+// Invalidate drops the cached entries matching the event. An entry cached without a
+// version may hold any version of the resource, so it is dropped for every version.
+func (h *AppHandler) Invalidate(event invalidation.Event) {
+	h.CacheMutex.Lock()
+	defer h.CacheMutex.Unlock()
+
+	for key := range h.Cache {
+		parts := strings.SplitN(key, "|", 4)
+		if len(parts) != 4 {
+			continue
+		}
+
+		collection, name, project, version := parts[0], parts[1], parts[2], parts[3]
+		if event.Collection != "" && event.Collection != collection {
+			continue
+		}
+		if event.Name != "" && event.Name != name {
+			continue
+		}
+		if event.Project != "" && event.Project != project {
+			continue
+		}
+		if event.Version != "" && version != "" && event.Version != version {
+			continue
+		}
+
+		delete(h.Cache, key)
+	}
+}
+
 func (h *AppHandler) StartCacheCleanup(interval time.Duration) {
 	go func() {
 		for {
@@ -57,30 +89,49 @@ func (h *AppHandler) cleanupCache() {
 	}
 }
 
-func (h *AppHandler) getResourceData(ctx context.Context, collection, name, project, version string) (string, string) {
-	cacheKey := fmt.Sprintf("%s|%s|%s", collection, name, project)
+// getResourceData looks a resource up in the request cache first, then in the
+// shared ttl cache and finally in mongo.
+func (t *traversal) getResourceData(ctx context.Context, collection, name, project string) (string, string) {
+	cacheKey := fmt.Sprintf("%s|%s|%s|%s", collection, name, project, t.version)
 
-	if cacheEntry, found := h.getCacheEntry(cacheKey); found {
+	t.cacheMu.Lock()
+	entry, found := t.cache[cacheKey]
+	t.cacheMu.Unlock()
+	if found {
+		return entry.ID, entry.JSON
+	}
+
+	if cacheEntry, found := t.handler.getCacheEntry(cacheKey); found {
+		t.setRequestCacheEntry(cacheKey, cacheEntry)
 		return cacheEntry.ID, cacheEntry.JSON
 	}
 
-	resource, err := resources.GetResourceNGeneral(ctx, h.Context, collection, name, project, version)
+	resource, err := resources.GetResourceNGeneral(ctx, t.handler.Context, collection, name, project, t.version)
 	if err != nil {
-		h.Logger.Debugf("Error fetching resource: %v", err)
+		t.handler.Logger.Debugf("Error fetching resource: %v", err)
+		t.setRequestCacheEntry(cacheKey, CacheEntry{})
 		return "", ""
 	}
 
 	resourceID := resource.ID.Hex()
-	jsonResource := helper.ConvertToJSON(resource, h.Logger.Logger)
+	jsonResource := helper.ConvertToJSON(resource, t.handler.Logger.Logger)
 
-	h.setCacheEntry(cacheKey, CacheEntry{
+	cacheEntry := CacheEntry{
 		ID:   resourceID,
 		JSON: jsonResource,
-	})
+	}
+	t.handler.setCacheEntry(cacheKey, cacheEntry)
+	t.setRequestCacheEntry(cacheKey, cacheEntry)
 
 	return resourceID, jsonResource
 }
 
+func (t *traversal) setRequestCacheEntry(key string, entry CacheEntry) {
+	t.cacheMu.Lock()
+	defer t.cacheMu.Unlock()
+	t.cache[key] = entry
+}
+
 func getDynamicJSONPaths(gtype models.GTypes) map[string]models.GTypes {
 	paths := gtype.UpstreamPaths()
 
@@ -8,11 +8,12 @@ import (
 
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models/downstreamfilters"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
 )
 
-func GenericUpstreamCollector(ctx context.Context, appCtx *AppHandler, activeResource Depend, version string) (Node, []Depend) {
+func (t *traversal) upstreamCollector(ctx context.Context, activeResource Depend) (Node, []Depend) {
 	var dependencies []Depend
-	id, jsonData := appCtx.getResourceData(ctx, activeResource.Collection, activeResource.Name, activeResource.Project, version)
+	id, jsonData := t.getResourceData(ctx, activeResource.Collection, activeResource.Name, activeResource.Project)
 	rootResult := gjson.Parse(jsonData)
 
 	node := Node{
@@ -28,60 +29,60 @@ func GenericUpstreamCollector(ctx context.Context, appCtx *AppHandler, activeRes
 	jsonPaths := getDynamicJSONPaths(activeResource.Gtype)
 	for path, gtype := range jsonPaths {
 		resourcePath := fmt.Sprintf("%s.%s", "resource.resource", path)
-		collectDependenciesFromPath(ctx, appCtx, rootResult, resourcePath, gtype, activeResource, &dependencies, version)
+		t.collectDependenciesFromPath(ctx, rootResult, resourcePath, gtype, activeResource, &dependencies)
 	}
 
-	dependencies = append(dependencies, parseTypedConfig(ctx, appCtx, rootResult, activeResource, version)...)
-	dependencies = append(dependencies, parseConfigDiscovery(ctx, appCtx, rootResult, activeResource, version)...)
+	dependencies = append(dependencies, t.parseTypedConfig(ctx, rootResult, activeResource)...)
+	dependencies = append(dependencies, t.parseConfigDiscovery(ctx, rootResult, activeResource)...)
 
 	if len(dependencies) == 0 {
-		appCtx.Logger.Debugf("No dependencies found for resource: %s of type %s", activeResource.Name, activeResource.Gtype)
+		t.handler.Logger.Debugf("No dependencies found for resource: %s of type %s", activeResource.Name, activeResource.Gtype)
 	}
 
 	return node, dependencies
 }
 
-func collectDependenciesFromPath(ctx context.Context, appCtx *AppHandler, rootResult gjson.Result, path string, gtype models.GTypes, activeResource Depend, dependencies *[]Depend, version string) {
+func (t *traversal) collectDependenciesFromPath(ctx context.Context, rootResult gjson.Result, path string, gtype models.GTypes, activeResource Depend, dependencies *[]Depend) {
 	results := rootResult.Get(path)
 
 	if !results.Exists() {
-		appCtx.Logger.Debugf("Result does not exist at path: %s", path)
+		t.handler.Logger.Debugf("Result does not exist at path: %s", path)
 		return
 	}
 
 	results.ForEach(func(_, item gjson.Result) bool {
 		if item.IsArray() {
 			item.ForEach(func(_, subItem gjson.Result) bool {
-				processItem(ctx, appCtx, subItem, path, gtype, activeResource, dependencies, version)
+				t.processItem(ctx, subItem, path, gtype, activeResource, dependencies)
 				return true
 			})
 		} else {
-			processItem(ctx, appCtx, item, path, gtype, activeResource, dependencies, version)
+			t.processItem(ctx, item, path, gtype, activeResource, dependencies)
 		}
 		return true
 	})
 }
 
-func processItem(ctx context.Context, appCtx *AppHandler, item gjson.Result, path string, gtype models.GTypes, activeResource Depend, dependencies *[]Depend, version string) {
+func (t *traversal) processItem(ctx context.Context, item gjson.Result, path string, gtype models.GTypes, activeResource Depend, dependencies *[]Depend) {
 	if item.IsArray() {
 		item.ForEach(func(_, subItem gjson.Result) bool {
-			addDependency(ctx, appCtx, subItem.String(), path, gtype, activeResource, dependencies, version)
+			t.addDependency(ctx, subItem.String(), path, gtype, activeResource, dependencies)
 			return true
 		})
 	} else {
-		addDependency(ctx, appCtx, item.String(), path, gtype, activeResource, dependencies, version)
+		t.addDependency(ctx, item.String(), path, gtype, activeResource, dependencies)
 	}
 }
 
-func addDependency(ctx context.Context, appCtx *AppHandler, name, path string, gtype models.GTypes, activeResource Depend, dependencies *[]Depend, version string) {
+func (t *traversal) addDependency(ctx context.Context, name, path string, gtype models.GTypes, activeResource Depend, dependencies *[]Depend) {
 	if name == "" {
-		appCtx.Logger.Debugf("Name not found at path: %s for gtype: %s", path, gtype)
+		t.handler.Logger.Debugf("Name not found at path: %s for gtype: %s", path, gtype)
 		return
 	}
 
-	itemID, _ := appCtx.getResourceData(ctx, gtype.CollectionString(), name, activeResource.Project, version)
+	itemID, _ := t.getResourceData(ctx, gtype.CollectionString(), name, activeResource.Project)
 	if itemID == "" {
-		appCtx.Logger.Debugf("ID not found for %s of type %s, skipping... Path: %s", name, gtype, path)
+		t.handler.Logger.Debugf("ID not found for %s of type %s, skipping... Path: %s", name, gtype, path)
 		return
 	}
 
@@ -95,22 +96,18 @@ func addDependency(ctx context.Context, appCtx *AppHandler, name, path string, g
 	}
 
 	*dependencies = append(*dependencies, dependency)
-	appCtx.Logger.Debugf("Added dependency: %s of type %s with ID: %s", dependency.Name, dependency.Gtype, dependency.ID)
+	t.handler.Logger.Debugf("Added dependency: %s of type %s with ID: %s", dependency.Name, dependency.Gtype, dependency.ID)
 }
 
-func GenericDownstreamCollector(ctx context.Context, appCtx *AppHandler, activeResource Depend, visited map[string]bool, version string) (Node, []Depend) {
+// downstreamCollector returns the direct downstreams of a resource, deeper
+// levels are expanded by ProcessDownstream.
+func (t *traversal) downstreamCollector(ctx context.Context, activeResource Depend) (Node, []Depend) {
 	var dependencies []Depend
 	if activeResource.ID == "" {
-		id, _ := appCtx.getResourceData(ctx, activeResource.Collection, activeResource.Name, activeResource.Project, version)
+		id, _ := t.getResourceData(ctx, activeResource.Collection, activeResource.Name, activeResource.Project)
 		activeResource.ID = id
 	}
 
-	uniqueKey := generateUniqueKey(activeResource)
-	if visited[uniqueKey] {
-		return Node{}, nil
-	}
-	visited[uniqueKey] = true
-
 	node := Node{
 		ID:         activeResource.ID,
 		Name:       activeResource.Name,
@@ -121,41 +118,64 @@ func GenericDownstreamCollector(ctx context.Context, appCtx *AppHandler, activeR
 		Direction:  "downstream",
 	}
 
+	if references.Ready(ctx, t.handler.Context.Client) {
+		referrers, err := references.Referrers(ctx, t.handler.Context.Client, activeResource.Project, t.version, activeResource.Gtype.CollectionString(), activeResource.Name)
+		if err == nil {
+			return node, t.dependenciesFromReferrers(ctx, referrers, activeResource)
+		}
+		t.handler.Logger.Debugf("Error fetching referrers, falling back to downstream filters: %v", err)
+	}
+
 	dfm := downstreamfilters.DownstreamFilter{
 		Name:    activeResource.Name,
 		Project: activeResource.Project,
-		Version: version,
+		Version: t.version,
 	}
 
 	downstreamFilters := activeResource.Gtype.DownstreamFilters(dfm)
 	for _, filter := range downstreamFilters {
-		collectDependenciesFromFilter(ctx, appCtx, filter, activeResource, &dependencies)
+		t.collectDependenciesFromFilter(ctx, filter, activeResource, &dependencies)
 	}
 
-	for _, dep := range dependencies {
-		if dep.Direction == "downstream" {
-			_, downstreamDeps := GenericDownstreamCollector(ctx, appCtx, dep, visited, version)
-			dependencies = append(dependencies, downstreamDeps...)
+	return node, dependencies
+}
+
+func (t *traversal) dependenciesFromReferrers(ctx context.Context, referrers []references.Endpoint, activeResource Depend) []Depend {
+	var dependencies []Depend
+	for _, referrer := range referrers {
+		id, _ := t.getResourceData(ctx, referrer.Collection, referrer.Name, activeResource.Project)
+		if id == "" {
+			t.handler.Logger.Debugf("Referrer %s of type %s not found, skipping...", referrer.Name, referrer.GType)
+			continue
 		}
-	}
 
-	return node, dependencies
+		dependencies = append(dependencies, Depend{
+			Name:       referrer.Name,
+			Gtype:      referrer.GType,
+			Collection: referrer.Collection,
+			Project:    activeResource.Project,
+			ID:         id,
+			Direction:  "downstream",
+			Source:     activeResource.ID,
+		})
+	}
+	return dependencies
 }
 
-func collectDependenciesFromFilter(ctx context.Context, appCtx *AppHandler, filter downstreamfilters.MongoFilters, activeResource Depend, dependencies *[]Depend) {
+func (t *traversal) collectDependenciesFromFilter(ctx context.Context, filter downstreamfilters.MongoFilters, activeResource Depend, dependencies *[]Depend) {
 	collection := filter.Collection
 	query := filter.Filter
 
-	cursor, err := appCtx.Context.Client.Collection(collection).Find(ctx, query)
+	cursor, err := t.handler.Context.Client.Collection(collection).Find(ctx, query)
 	if err != nil {
-		appCtx.Logger.Debugf("Error fetching downstream dependencies: %v", err)
+		t.handler.Logger.Debugf("Error fetching downstream dependencies: %v", err)
 		return
 	}
 
 	for cursor.Next(ctx) {
 		var resource models.DBResource
 		if err := cursor.Decode(&resource); err != nil {
-			appCtx.Logger.Debugf("Error decoding downstream resource: %v", err)
+			t.handler.Logger.Debugf("Error decoding downstream resource: %v", err)
 			continue
 		}
 
@@ -174,6 +194,6 @@ func collectDependenciesFromFilter(ctx context.Context, appCtx *AppHandler, filt
 		}
 
 		*dependencies = append(*dependencies, dependency)
-		appCtx.Logger.Debugf("Added downstream dependency: %s of type %s with ID: %s", dependency.Name, dependency.Gtype, dependency.ID)
+		t.handler.Logger.Debugf("Added downstream dependency: %s of type %s with ID: %s", dependency.Name, dependency.Gtype, dependency.ID)
 	}
 }
@@ -8,7 +8,7 @@ import (
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 )
 
-func parseConfigDiscovery(ctx context.Context, appCtx *AppHandler, rootResult gjson.Result, activeResource Depend, version string) []Depend {
+func (t *traversal) parseConfigDiscovery(ctx context.Context, rootResult gjson.Result, activeResource Depend) []Depend {
 	var dependencies []Depend
 
 	rootResult.Get("general.config_discovery").ForEach(func(_, discoveryItem gjson.Result) bool {
@@ -19,7 +19,7 @@ func parseConfigDiscovery(ctx context.Context, appCtx *AppHandler, rootResult gj
 
 		gtype := models.GTypes(gtypeStr)
 		cdName := discoveryItem.Get("name").String()
-		cdID, _ := appCtx.getResourceData(ctx, gtype.CollectionString(), cdName, activeResource.Project, version)
+		cdID, _ := t.getResourceData(ctx, gtype.CollectionString(), cdName, activeResource.Project)
 		dependencies = append(dependencies, Depend{Name: cdName, Gtype: gtype, Collection: gtype.CollectionString(), Project: activeResource.Project, ID: cdID})
 		return true
 	})
@@ -27,7 +27,7 @@ func parseConfigDiscovery(ctx context.Context, appCtx *AppHandler, rootResult gj
 	return dependencies
 }
 
-func parseTypedConfig(ctx context.Context, appCtx *AppHandler, rootResult gjson.Result, activeResource Depend, version string) []Depend {
+func (t *traversal) parseTypedConfig(ctx context.Context, rootResult gjson.Result, activeResource Depend) []Depend {
 	var dependencies []Depend
 
 	rootResult.Get("general.typed_config").ForEach(func(_, typedItem gjson.Result) bool {
@@ -38,7 +38,7 @@ func parseTypedConfig(ctx context.Context, appCtx *AppHandler, rootResult gjson.
 
 		gtype := models.GTypes(gtypeStr)
 		tcName := typedItem.Get("name").String()
-		tcID, _ := appCtx.getResourceData(ctx, gtype.CollectionString(), tcName, activeResource.Project, version)
+		tcID, _ := t.getResourceData(ctx, gtype.CollectionString(), tcName, activeResource.Project)
 		dependencies = append(dependencies, Depend{Name: tcName, Gtype: gtype, Collection: gtype.CollectionString(), Project: activeResource.Project, ID: tcID})
 		return true
 	})
@@ -0,0 +1,225 @@
+package dependency
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatGraphML = "graphml"
+)
+
+var formatContentTypes = map[string]string{
+	FormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	FormatMermaid: "text/plain; charset=utf-8",
+	FormatGraphML: "application/graphml+xml; charset=utf-8",
+}
+
+// ExportProjectDependencies builds one graph from the upstream side of every listener in the project.
+func (h *AppHandler) ExportProjectDependencies(ctx context.Context, requestDetails models.RequestDetails) (*Graph, error) {
+	if requestDetails.Project == "" {
+		return nil, errors.New("project is required")
+	}
+
+	filter := bson.M{"general.project": requestDetails.Project}
+	if requestDetails.Version != "" {
+		filter["general.version"] = requestDetails.Version
+	}
+
+	opts := options.Find().SetProjection(bson.M{"general.name": 1}).SetSort(bson.M{"general.name": 1})
+	cursor, err := h.Context.Client.Collection("listeners").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not find listeners: %w", err)
+	}
+
+	var listeners []GeneralName
+	if err := cursor.All(ctx, &listeners); err != nil {
+		return nil, fmt.Errorf("could not decode listeners: %w", err)
+	}
+
+	t := h.newTraversal(requestDetails.Version)
+	for _, listener := range listeners {
+		t.ProcessUpstream(ctx, Depend{
+			Collection: "listeners",
+			Name:       listener.General.Name,
+			Gtype:      models.Listener,
+			Project:    requestDetails.Project,
+			First:      true,
+		})
+	}
+
+	return t.graph, nil
+}
+
+type GeneralName struct {
+	General struct {
+		Name string `bson:"name"`
+	} `bson:"general"`
+}
+
+// RenderGraph renders the graph in a text format, it returns the body and its content type.
+func RenderGraph(graph *Graph, format, version string) (string, string, error) {
+	var body string
+	switch format {
+	case FormatDOT:
+		body = renderDOT(graph, version)
+	case FormatMermaid:
+		body = renderMermaid(graph, version)
+	case FormatGraphML:
+		out, err := renderGraphML(graph, version)
+		if err != nil {
+			return "", "", err
+		}
+		body = out
+	default:
+		return "", "", fmt.Errorf("unsupported format: %s", format)
+	}
+
+	return body, formatContentTypes[format], nil
+}
+
+func nodeLabel(node Dependency, version string, separator string) string {
+	parts := []string{node.Data.Label, node.Data.Gtype, node.Data.Category}
+	if version != "" {
+		parts = append(parts, version)
+	}
+	return strings.Join(parts, separator)
+}
+
+func renderDOT(graph *Graph, version string) string {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("  rankdir=LR;\n  node [shape=box];\n")
+
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s];\n", dotQuote(node.Data.ID), dotQuote(nodeLabel(node, version, "\n")))
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s, direction=%s];\n",
+			dotQuote(edge.Data.Source), dotQuote(edge.Data.Target),
+			dotQuote(edge.Data.Label), dotQuote(edge.Data.Direction))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func renderMermaid(graph *Graph, version string) string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&b, "  n%s[\"%s\"]\n", node.Data.ID, mermaidEscape(nodeLabel(node, version, "<br/>")))
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&b, "  n%s -->|\"%s\"| n%s\n", edge.Data.Source, mermaidEscape(edge.Data.Label+" ("+edge.Data.Direction+")"), edge.Data.Target)
+	}
+
+	return b.String()
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func renderGraphML(graph *Graph, version string) (string, error) {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", AttrName: "label", AttrType: "string"},
+			{ID: "gtype", For: "node", AttrName: "gtype", AttrType: "string"},
+			{ID: "collection", For: "node", AttrName: "collection", AttrType: "string"},
+			{ID: "version", For: "node", AttrName: "version", AttrType: "string"},
+			{ID: "first", For: "node", AttrName: "first", AttrType: "boolean"},
+			{ID: "elabel", For: "edge", AttrName: "label", AttrType: "string"},
+			{ID: "direction", For: "edge", AttrName: "direction", AttrType: "string"},
+		},
+		Graph: graphMLGraph{ID: "dependencies", EdgeDefault: "directed"},
+	}
+
+	for _, node := range graph.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: node.Data.ID,
+			Data: []graphMLData{
+				{Key: "label", Value: node.Data.Label},
+				{Key: "gtype", Value: node.Data.Gtype},
+				{Key: "collection", Value: node.Data.Category},
+				{Key: "version", Value: version},
+				{Key: "first", Value: fmt.Sprintf("%t", node.Data.First)},
+			},
+		})
+	}
+
+	for _, edge := range graph.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: edge.Data.Source,
+			Target: edge.Data.Target,
+			Data: []graphMLData{
+				{Key: "elabel", Value: edge.Data.Label},
+				{Key: "direction", Value: edge.Data.Direction},
+			},
+		})
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(out) + "\n", nil
+}
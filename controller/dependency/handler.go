@@ -10,21 +10,25 @@ import (
 )
 
 type AppHandler struct {
-	Context      *db.AppContext
-	Dependencies *Graph
-	Cache        map[string]CacheEntry
-	CacheMutex   sync.Mutex
-	Version      string
-	Logger       *logger.Logger
+	Context        *db.AppContext
+	Cache          map[string]CacheEntry
+	CacheMutex     sync.Mutex
+	MaxDepth       int
+	MaxConcurrency int
+	Logger         *logger.Logger
 }
 
 func NewDependencyHandler(context *db.AppContext) *AppHandler {
-	return &AppHandler{
-		Context:      context,
-		Dependencies: &Graph{},
-		Cache:        make(map[string]CacheEntry),
-		Logger:       logger.NewLogger("controller/dependency"),
+	handler := &AppHandler{
+		Context:        context,
+		Cache:          make(map[string]CacheEntry),
+		MaxDepth:       defaultMaxDepth,
+		MaxConcurrency: defaultMaxConcurrency,
+		Logger:         logger.NewLogger("controller/dependency"),
 	}
+	context.Invalidation.Subscribe(handler.Invalidate)
+
+	return handler
 }
 
 func (h *AppHandler) GetResourceDependencies(ctx context.Context, requestDetails models.RequestDetails) (*Graph, error) {
@@ -36,17 +40,8 @@ func (h *AppHandler) GetResourceDependencies(ctx context.Context, requestDetails
 		First:      true,
 	}
 
-	h.Dependencies = &Graph{}
-	h.ProcessResource(ctx, activeResource, requestDetails.Version)
-
-	return h.Dependencies, nil
-}
-
-func (h *AppHandler) CallUpstreamFunction(ctx context.Context, activeResource Depend) (Node, []Depend) {
-	return GenericUpstreamCollector(ctx, h, activeResource, h.Version)
-}
+	t := h.newTraversal(requestDetails.Version)
+	t.ProcessResource(ctx, activeResource)
 
-func (h *AppHandler) CallDownstreamFunction(ctx context.Context, activeResource Depend) (Node, []Depend) {
-	visited := make(map[string]bool)
-	return GenericDownstreamCollector(ctx, h, activeResource, visited, h.Version)
+	return t.graph, nil
 }
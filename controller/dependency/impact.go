@@ -0,0 +1,187 @@
+package dependency
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+type Impact struct {
+	Resource          ImpactResource   `json:"resource"`
+	AffectedResources []ImpactResource `json:"affected_resources"`
+	Listeners         []ListenerImpact `json:"listeners"`
+	Summary           ImpactSummary    `json:"summary"`
+}
+
+type ImpactResource struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Gtype      string `json:"gtype"`
+	Collection string `json:"collection"`
+}
+
+type ListenerImpact struct {
+	ImpactResource
+	Service   *ImpactService `json:"service"`
+	Nodes     []ImpactNode   `json:"nodes"`
+	Connected int            `json:"connected"`
+	Offline   int            `json:"offline"`
+}
+
+type ImpactService struct {
+	Name      string                  `json:"name"`
+	AdminPort uint32                  `json:"admin_port"`
+	Clients   []models.ListenerClient `json:"clients"`
+}
+
+type ImpactNode struct {
+	NodeID            string `json:"node_id"`
+	DownstreamAddress string `json:"downstream_address"`
+	ClientID          string `json:"client_id,omitempty"`
+	ClientName        string `json:"client_name,omitempty"`
+	EnvoyVersion      string `json:"envoy_version,omitempty"`
+	LastSync          int64  `json:"last_sync,omitempty"`
+	Connected         bool   `json:"connected"`
+}
+
+type ImpactSummary struct {
+	AffectedResources int `json:"affected_resources"`
+	Listeners         int `json:"listeners"`
+	Envoys            int `json:"envoys"`
+	Connected         int `json:"connected"`
+	Offline           int `json:"offline"`
+}
+
+// GetResourceImpact walks the downstream side of a resource without poking and
+// resolves every affected listener to its service, clients and live envoys.
+func (h *AppHandler) GetResourceImpact(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+	activeResource := Depend{
+		Collection: requestDetails.Collection,
+		Name:       requestDetails.Name,
+		Gtype:      requestDetails.GType,
+		Project:    requestDetails.Project,
+		First:      true,
+	}
+
+	t := h.newTraversal(requestDetails.Version)
+	t.ProcessDownstream(ctx, activeResource)
+
+	impact := &Impact{
+		Resource: ImpactResource{
+			Name:       requestDetails.Name,
+			Gtype:      requestDetails.GType.String(),
+			Collection: requestDetails.Collection,
+		},
+		AffectedResources: []ImpactResource{},
+		Listeners:         []ListenerImpact{},
+	}
+
+	for _, node := range t.graph.Nodes {
+		resource := ImpactResource{
+			ID:         node.Data.ID,
+			Name:       node.Data.Label,
+			Gtype:      node.Data.Gtype,
+			Collection: node.Data.Category,
+		}
+
+		if node.Data.First {
+			impact.Resource = resource
+		} else {
+			impact.AffectedResources = append(impact.AffectedResources, resource)
+		}
+
+		if models.GTypes(node.Data.Gtype) != models.Listener {
+			continue
+		}
+
+		listener, err := h.resolveListener(ctx, resource, requestDetails.Project)
+		if err != nil {
+			return nil, err
+		}
+		impact.Listeners = append(impact.Listeners, listener)
+	}
+
+	sort.Slice(impact.Listeners, func(i, j int) bool {
+		return impact.Listeners[i].Name < impact.Listeners[j].Name
+	})
+
+	impact.Summary.AffectedResources = len(impact.AffectedResources)
+	impact.Summary.Listeners = len(impact.Listeners)
+	for _, listener := range impact.Listeners {
+		impact.Summary.Envoys += len(listener.Nodes)
+		impact.Summary.Connected += listener.Connected
+		impact.Summary.Offline += listener.Offline
+	}
+
+	return impact, nil
+}
+
+func (h *AppHandler) resolveListener(ctx context.Context, resource ImpactResource, project string) (ListenerImpact, error) {
+	listener := ListenerImpact{ImpactResource: resource, Nodes: []ImpactNode{}}
+	filter := bson.M{"name": resource.Name, "project": project}
+
+	var service models.Service
+	err := h.Context.Client.Collection("services").FindOne(ctx, filter).Decode(&service)
+	switch {
+	case err == nil:
+		listener.Service = &ImpactService{Name: service.Name, AdminPort: service.AdminPort, Clients: service.Clients}
+	case !errors.Is(err, mongo.ErrNoDocuments):
+		return listener, err
+	}
+
+	var envoys models.Envoys
+	err = h.Context.Client.Collection("envoys").FindOne(ctx, filter).Decode(&envoys)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return listener, err
+	}
+
+	seen := make(map[string]bool)
+	for _, envoy := range envoys.Envoys {
+		node := ImpactNode{
+			NodeID:            envoy.NodeID,
+			DownstreamAddress: envoy.DownstreamAddr,
+			ClientName:        envoy.ClientName,
+			EnvoyVersion:      envoy.Version,
+			LastSync:          envoy.LastSync,
+			Connected:         envoy.Connected,
+		}
+		if listener.Service != nil {
+			for _, client := range listener.Service.Clients {
+				if client.DownstreamAddress == envoy.DownstreamAddr {
+					node.ClientID = client.ClientID
+				}
+			}
+		}
+		seen[envoy.DownstreamAddr] = true
+		listener.Nodes = append(listener.Nodes, node)
+	}
+
+	// clients registered on the service that never reported to the control plane
+	if listener.Service != nil {
+		for _, client := range listener.Service.Clients {
+			if seen[client.DownstreamAddress] {
+				continue
+			}
+			listener.Nodes = append(listener.Nodes, ImpactNode{
+				NodeID:            resource.Name + "::" + project + "::" + client.DownstreamAddress,
+				DownstreamAddress: client.DownstreamAddress,
+				ClientID:          client.ClientID,
+			})
+		}
+	}
+
+	for _, node := range listener.Nodes {
+		if node.Connected {
+			listener.Connected++
+		} else {
+			listener.Offline++
+		}
+	}
+
+	return listener, nil
+}
@@ -13,12 +13,6 @@ type CacheEntry struct {
 	TTL       time.Duration
 }
 
-
-
-func (h *AppHandler) SetVersion(version string) {
-	h.Version = version
-}
-
 type Dependency struct {
 	Data struct {
 		ID        string `json:"id"`
@@ -37,11 +31,14 @@ type Graph struct {
 }
 
 type Edge struct {
-	Data struct {
-		Source string `json:"source"`
-		Target string `json:"target"`
-		Label  string `json:"label"`
-	} `json:"data"`
+	Data EdgeData `json:"data"`
+}
+
+type EdgeData struct {
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Label     string `json:"label"`
+	Direction string `json:"direction"`
 }
 
 type Depend struct {
@@ -1,13 +1,16 @@
 package dependency
 
-func (h *AppHandler) AddNode(node Node) {
+func (t *traversal) AddNode(node Node) {
 	if node.ID == "" || node.Name == "" {
-		h.Logger.Debugf("An empty or missing value node detected, not added: %+v\n", node)
+		t.handler.Logger.Debugf("An empty or missing value node detected, not added: %+v\n", node)
 		return
 	}
 
-	if h.isNodeAlreadyAdded(node.ID) {
-		h.Logger.Debugf("Node already added: %s\n", node.ID)
+	t.graphMu.Lock()
+	defer t.graphMu.Unlock()
+
+	if _, exists := t.nodeIndex[node.ID]; exists {
+		t.handler.Logger.Debugf("Node already added: %s\n", node.ID)
 		return
 	}
 
@@ -31,60 +34,42 @@ func (h *AppHandler) AddNode(node Node) {
 		},
 	}
 
-	h.Logger.Debugf("Adding node: %+v\n", node)
-	h.Dependencies.Nodes = append(h.Dependencies.Nodes, dependency)
+	t.handler.Logger.Debugf("Adding node: %+v\n", node)
+	t.nodeIndex[node.ID] = struct{}{}
+	t.graph.Nodes = append(t.graph.Nodes, dependency)
 }
 
-func (h *AppHandler) AddNodeAndEdge(source Node, target Depend, isUpstream bool) {
+func (t *traversal) AddNodeAndEdge(source Node, target Depend, isUpstream bool) {
 	var edge Edge
 	if isUpstream {
 		edge = Edge{
-			Data: struct {
-				Source string `json:"source"`
-				Target string `json:"target"`
-				Label  string `json:"label"`
-			}{
-				Source: source.ID,
-				Target: target.ID,
-				Label:  source.Gtype.PrettyName() + " to " + target.Gtype.PrettyName(),
+			Data: EdgeData{
+				Source:    source.ID,
+				Target:    target.ID,
+				Label:     source.Gtype.PrettyName() + " to " + target.Gtype.PrettyName(),
+				Direction: "upstream",
 			},
 		}
 	} else {
 		edge = Edge{
-			Data: struct {
-				Source string `json:"source"`
-				Target string `json:"target"`
-				Label  string `json:"label"`
-			}{
-				Source: target.ID,
-				Target: source.ID,
-				Label:  target.Gtype.PrettyName() + " to " + source.Gtype.PrettyName(),
+			Data: EdgeData{
+				Source:    target.ID,
+				Target:    source.ID,
+				Label:     target.Gtype.PrettyName() + " to " + source.Gtype.PrettyName(),
+				Direction: "downstream",
 			},
 		}
 	}
 
-	if edge.Data.Source != edge.Data.Target && !h.isEdgeAlreadyAdded(edge.Data.Source, edge.Data.Target) {
-		h.Logger.Debugf("Adding edge: %+v\n", edge)
-		h.Dependencies.Edges = append(h.Dependencies.Edges, edge)
-	} else {
-		h.Logger.Debugf("Skipping self or existing edge: %+v\n", edge)
-	}
-}
-
-func (h *AppHandler) isNodeAlreadyAdded(nodeID string) bool {
-	for _, node := range h.Dependencies.Nodes {
-		if node.Data.ID == nodeID {
-			return true
-		}
-	}
-	return false
-}
+	t.graphMu.Lock()
+	defer t.graphMu.Unlock()
 
-func (h *AppHandler) isEdgeAlreadyAdded(source, target string) bool {
-	for _, edge := range h.Dependencies.Edges {
-		if edge.Data.Source == source && edge.Data.Target == target {
-			return true
-		}
+	edgeKey := edge.Data.Source + "|" + edge.Data.Target
+	if _, exists := t.edgeIndex[edgeKey]; edge.Data.Source != edge.Data.Target && !exists {
+		t.handler.Logger.Debugf("Adding edge: %+v\n", edge)
+		t.edgeIndex[edgeKey] = struct{}{}
+		t.graph.Edges = append(t.graph.Edges, edge)
+	} else {
+		t.handler.Logger.Debugf("Skipping self or existing edge: %+v\n", edge)
 	}
-	return false
 }
@@ -0,0 +1,276 @@
+package dependency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/drafts"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
+)
+
+// rootCollections are the entry points of the upstream walk, they are never reported as orphans.
+var rootCollections = map[string]models.GTypes{
+	"listeners": models.Listener,
+	"bootstrap": models.BootStrap,
+}
+
+type OrphanReport struct {
+	Project string           `json:"project"`
+	Version string           `json:"version,omitempty"`
+	Scanned int              `json:"scanned"`
+	Counts  map[string]int   `json:"counts"`
+	Orphans []OrphanResource `json:"orphans"`
+}
+
+type OrphanResource struct {
+	ID         string             `json:"id"`
+	Name       string             `json:"name"`
+	Gtype      string             `json:"gtype"`
+	Collection string             `json:"collection"`
+	Version    string             `json:"version"`
+	UpdatedAt  primitive.DateTime `json:"updated_at"`
+	Default    bool               `json:"default"`
+}
+
+type OrphanDeleteResult struct {
+	Deleted []OrphanResource `json:"deleted"`
+	Skipped []OrphanResource `json:"skipped"`
+}
+
+type resourceSummary struct {
+	ID      primitive.ObjectID `bson:"_id"`
+	General struct {
+		Name      string             `bson:"name"`
+		GType     string             `bson:"gtype"`
+		Version   string             `bson:"version"`
+		UpdatedAt primitive.DateTime `bson:"updated_at"`
+	} `bson:"general"`
+}
+
+// GetOrphanReport lists every xds resource of the project that is not reachable
+// from any listener or bootstrap.
+func (h *AppHandler) GetOrphanReport(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+	return h.findOrphans(ctx, requestDetails)
+}
+
+func (h *AppHandler) findOrphans(ctx context.Context, requestDetails models.RequestDetails) (*OrphanReport, error) {
+	if requestDetails.Project == "" {
+		return nil, errors.New("project is required")
+	}
+
+	filter := bson.M{"general.project": requestDetails.Project}
+	if requestDetails.Version != "" {
+		filter["general.version"] = requestDetails.Version
+	}
+
+	reachable, err := h.reachableResources(ctx, filter, requestDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OrphanReport{
+		Project: requestDetails.Project,
+		Version: requestDetails.Version,
+		Counts:  map[string]int{},
+		Orphans: []OrphanResource{},
+	}
+
+	for _, collection := range models.XDSCollections() {
+		if _, isRoot := rootCollections[collection]; isRoot {
+			continue
+		}
+
+		resources, err := h.findSummaries(ctx, collection, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range resources {
+			report.Scanned++
+			if reachable[resource.ID.Hex()] {
+				continue
+			}
+
+			isDefault, err := common.IsDefaultResource(ctx, h.Context, resource.General.Name, collection, requestDetails.Project)
+			if err != nil {
+				h.Logger.Errorf("An error occurred while checking if the resource is default: %v", err)
+			}
+
+			report.Counts[collection]++
+			report.Orphans = append(report.Orphans, OrphanResource{
+				ID:         resource.ID.Hex(),
+				Name:       resource.General.Name,
+				Gtype:      resource.General.GType,
+				Collection: collection,
+				Version:    resource.General.Version,
+				UpdatedAt:  resource.General.UpdatedAt,
+				Default:    isDefault,
+			})
+		}
+	}
+
+	sort.SliceStable(report.Orphans, func(i, j int) bool {
+		return report.Orphans[i].UpdatedAt < report.Orphans[j].UpdatedAt
+	})
+
+	return report, nil
+}
+
+// reachableResources walks the upstream side of every root per version and
+// returns the ids of all resources seen on the way.
+func (h *AppHandler) reachableResources(ctx context.Context, filter bson.M, project string) (map[string]bool, error) {
+	reachable := make(map[string]bool)
+	traversals := make(map[string]*traversal)
+
+	for collection, gtype := range rootCollections {
+		roots, err := h.findSummaries(ctx, collection, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, root := range roots {
+			t, ok := traversals[root.General.Version]
+			if !ok {
+				t = h.newTraversal(root.General.Version)
+				traversals[root.General.Version] = t
+			}
+
+			t.ProcessUpstream(ctx, Depend{
+				Collection: collection,
+				Name:       root.General.Name,
+				Gtype:      gtype,
+				Project:    project,
+				First:      true,
+			})
+		}
+	}
+
+	for _, t := range traversals {
+		for _, node := range t.graph.Nodes {
+			reachable[node.Data.ID] = true
+		}
+	}
+
+	return reachable, nil
+}
+
+func (h *AppHandler) findSummaries(ctx context.Context, collection string, filter bson.M) ([]resourceSummary, error) {
+	opts := options.Find().SetProjection(bson.M{
+		"_id":                1,
+		"general.name":       1,
+		"general.gtype":      1,
+		"general.version":    1,
+		"general.updated_at": 1,
+	})
+
+	cursor, err := h.Context.Client.Collection(collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not find %s: %w", collection, err)
+	}
+
+	var results []resourceSummary
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("could not decode %s: %w", collection, err)
+	}
+
+	return results, nil
+}
+
+// DeleteOrphans recomputes the report and moves what is still unreachable to the
+// trash. Default resources and those the user cannot access are skipped, the optional
+// collection narrows the delete.
+func (h *AppHandler) DeleteOrphans(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+	report, err := h.findOrphans(ctx, requestDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	result := OrphanDeleteResult{Deleted: []OrphanResource{}, Skipped: []OrphanResource{}}
+	for _, orphan := range report.Orphans {
+		if requestDetails.Collection != "" && orphan.Collection != requestDetails.Collection {
+			continue
+		}
+
+		if orphan.Default {
+			result.Skipped = append(result.Skipped, orphan)
+			continue
+		}
+
+		deleted, err := h.deleteOrphan(ctx, requestDetails, orphan)
+		if err != nil {
+			h.Logger.Errorf("Could not delete orphan %s/%s: %v", orphan.Collection, orphan.Name, err)
+			result.Skipped = append(result.Skipped, orphan)
+			continue
+		}
+
+		if deleted != nil {
+			crud.RecordRevision(ctx, h.Context, revisions.OperationDelete, deleted, requestDetails.User)
+		}
+
+		if err := resources.RemoveReferences(ctx, h.Context, orphan.Collection, orphan.Name, requestDetails.Project, orphan.Version); err != nil {
+			h.Logger.Errorf("Could not remove references of orphan %s/%s: %v", orphan.Collection, orphan.Name, err)
+		}
+		if err := drafts.RemoveFor(ctx, h.Context.Client, orphan.Collection, orphan.Name, requestDetails.Project, orphan.Version); err != nil {
+			h.Logger.Errorf("%v", err)
+		}
+		h.Context.Invalidation.Publish(invalidation.Event{
+			Collection: orphan.Collection,
+			Name:       orphan.Name,
+			Project:    requestDetails.Project,
+			Version:    orphan.Version,
+		})
+		result.Deleted = append(result.Deleted, orphan)
+	}
+
+	return result, nil
+}
+
+// deleteOrphan moves an orphan the user can access to the trash and deletes it in one
+// transaction, it returns the deleted resource for the revision history.
+func (h *AppHandler) deleteOrphan(ctx context.Context, requestDetails models.RequestDetails, orphan OrphanResource) (*models.DBResource, error) {
+	oid, err := primitive.ObjectIDFromHex(orphan.ID)
+	if err != nil {
+		return nil, errors.New("invalid id format")
+	}
+
+	details := requestDetails
+	details.Collection = orphan.Collection
+	details.Name = orphan.Name
+	details.Version = orphan.Version
+	filter := common.AddUserFilter(details, bson.M{"_id": oid})
+
+	deleted, err := revisions.LoadResource(ctx, h.Context.Client, orphan.Collection, filter)
+	if err != nil {
+		h.Logger.Errorf("Could not load orphan %s/%s before delete: %v", orphan.Collection, orphan.Name, err)
+	}
+
+	err = crud.InTransaction(ctx, h.Context, func(sc context.Context) error {
+		if err := crud.MoveToTrash(sc, h.Context, details, orphan.Collection, filter); err != nil {
+			return err
+		}
+		res, err := h.Context.Client.Collection(orphan.Collection).DeleteOne(sc, filter)
+		if err != nil {
+			return errstr.ErrUnknownDBError
+		}
+		if res.DeletedCount == 0 {
+			return errstr.ErrNoDocumentsDelete
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
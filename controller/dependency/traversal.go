@@ -0,0 +1,73 @@
+package dependency
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	defaultMaxDepth       = 32
+	defaultMaxConcurrency = 8
+)
+
+// traversal holds everything that belongs to a single dependency request.
+// Nothing in it is shared with other requests, so concurrent requests do not
+// see each other's visited sets or graphs.
+type traversal struct {
+	handler  *AppHandler
+	version  string
+	maxDepth int
+	sem      chan struct{}
+
+	graph     *Graph
+	graphMu   sync.Mutex
+	nodeIndex map[string]struct{}
+	edgeIndex map[string]struct{}
+
+	cache   map[string]CacheEntry
+	cacheMu sync.Mutex
+}
+
+func (h *AppHandler) newTraversal(version string) *traversal {
+	return &traversal{
+		handler:   h,
+		version:   version,
+		maxDepth:  h.MaxDepth,
+		sem:       make(chan struct{}, h.MaxConcurrency),
+		graph:     &Graph{},
+		nodeIndex: make(map[string]struct{}),
+		edgeIndex: make(map[string]struct{}),
+		cache:     make(map[string]CacheEntry),
+	}
+}
+
+type levelResult struct {
+	node Node
+	deps []Depend
+}
+
+// collectLevel runs collect for every resource of a level, bounded by the
+// traversal semaphore. Results keep the order of the level so the graph is
+// built deterministically.
+func (t *traversal) collectLevel(ctx context.Context, level []Depend, collect func(context.Context, Depend) (Node, []Depend)) []levelResult {
+	results := make([]levelResult, len(level))
+	var wg sync.WaitGroup
+
+	for i, resource := range level {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		t.sem <- struct{}{}
+		go func(i int, resource Depend) {
+			defer wg.Done()
+			defer func() { <-t.sem }()
+			node, deps := collect(ctx, resource)
+			results[i] = levelResult{node: node, deps: deps}
+		}(i, resource)
+	}
+
+	wg.Wait()
+	return results
+}
@@ -2,15 +2,31 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/CloudNativeWorks/elchi-backend/controller/api/auth"
 	"github.com/CloudNativeWorks/elchi-backend/controller/bridge"
 	"github.com/CloudNativeWorks/elchi-backend/controller/client"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/bundle"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/cascade"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/changeset"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/clone"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/custom"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/draft"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/extension"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/lints"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/relabel"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/rename"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/revision"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/scenario"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/schemas"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/search"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/trashbin"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/upgrade"
 	"github.com/CloudNativeWorks/elchi-backend/controller/crud/xds"
 	"github.com/CloudNativeWorks/elchi-backend/controller/dependency"
 	"github.com/CloudNativeWorks/elchi-backend/controller/service"
@@ -28,6 +44,7 @@ const (
 type (
 	ResFunc      func(ctx context.Context, resource models.ResourceClass, requestDetails models.RequestDetails) (any, error)
 	DepFunc      func(ctx context.Context, requestDetails models.RequestDetails) (*dependency.Graph, error)
+	ReportFunc   func(ctx context.Context, requestDetails models.RequestDetails) (any, error)
 	ScenarioFunc func(ctx context.Context, scenario models.ScenarioBody, reqDetails models.RequestDetails) (any, error)
 	OpFunc       func(ctx context.Context, operation models.OperationClass, requestDetails models.RequestDetails) (any, error)
 )
@@ -42,9 +59,22 @@ type Handler struct {
 	Scenario   *scenario.AppHandler
 	Client     *client.AppHandler
 	Service    *service.AppHandler
+	Revision   *revision.AppHandler
+	ChangeSet  *changeset.AppHandler
+	Draft      *draft.AppHandler
+	Bundle     *bundle.AppHandler
+	Upgrade    *upgrade.AppHandler
+	Rename     *rename.AppHandler
+	Clone      *clone.AppHandler
+	Search     *search.AppHandler
+	Relabel    *relabel.AppHandler
+	Trash      *trashbin.AppHandler
+	Cascade    *cascade.AppHandler
+	Schema     *schemas.AppHandler
+	Lint       *lints.AppHandler
 }
 
-func NewHandler(xds *xds.AppHandler, extension *extension.AppHandler, custom *custom.AppHandler, auth *auth.AppHandler, dependency *dependency.AppHandler, stats *bridge.AppHandler, scenario *scenario.AppHandler, client *client.AppHandler, service *service.AppHandler) *Handler {
+func NewHandler(xds *xds.AppHandler, extension *extension.AppHandler, custom *custom.AppHandler, auth *auth.AppHandler, dependency *dependency.AppHandler, stats *bridge.AppHandler, scenario *scenario.AppHandler, client *client.AppHandler, service *service.AppHandler, revision *revision.AppHandler, changeSet *changeset.AppHandler, draft *draft.AppHandler, bundle *bundle.AppHandler, upgrade *upgrade.AppHandler, rename *rename.AppHandler, clone *clone.AppHandler, search *search.AppHandler, relabel *relabel.AppHandler, trash *trashbin.AppHandler, cascade *cascade.AppHandler, schema *schemas.AppHandler, lint *lints.AppHandler) *Handler {
 	return &Handler{
 		XDS:        xds,
 		Extension:  extension,
@@ -55,6 +85,19 @@ func NewHandler(xds *xds.AppHandler, extension *extension.AppHandler, custom *cu
 		Scenario:   scenario,
 		Client:     client,
 		Service:    service,
+		Revision:   revision,
+		ChangeSet:  changeSet,
+		Draft:      draft,
+		Bundle:     bundle,
+		Upgrade:    upgrade,
+		Rename:     rename,
+		Clone:      clone,
+		Search:     search,
+		Relabel:    relabel,
+		Trash:      trash,
+		Cascade:    cascade,
+		Schema:     schema,
+		Lint:       lint,
 	}
 }
 
@@ -74,6 +117,9 @@ func (h *Handler) handleRequest(c *gin.Context, resFunc ResFunc) {
 
 	response, err := h.dynamicFuncs(c, ctx, resFunc, requestDetails)
 	if err != nil {
+		if writeConflict(c, err) {
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error(), "data": response})
 		return
 	}
@@ -113,7 +159,7 @@ func (h *Handler) getRequestDetails(c *gin.Context) (models.RequestDetails, mode
 		Category:       c.Query("category"),
 		ResourceID:     c.Query("resource_id"),
 		Name:           c.Param("name"),
-		SaveOrPublish:  c.Query("save_or_publish"),
+		SaveOrPublish:  getSaveOrPublish(c),
 		Project:        c.Query("project"),
 		Metadata:       extractMetadata(c),
 		Type:           models.KnownTYPES(getOptionalParam(c, "type")),
@@ -121,6 +167,10 @@ func (h *Handler) getRequestDetails(c *gin.Context) (models.RequestDetails, mode
 		User:           userDetails,
 		WithServiceIPs: c.Query("with_service_ips"),
 		ForMetrics:     c.Query("for_metrics"),
+		IfMatch:        parseIfMatch(c.GetHeader("If-Match")),
+		List:           parseListOptions(c),
+		Selector:       c.Query("selector"),
+		Permanent:      c.Query("permanent"),
 	}
 
 	return requestDetails, userDetails
@@ -162,6 +212,7 @@ func GetUserDetails(c *gin.Context) (models.UserDetails, error) {
 	projects, _ := c.Get("projects")
 	userName, _ := c.Get("user_name")
 	BaseGroup, _ := c.Get("base_group")
+	labelScopes, _ := c.Get("label_scopes")
 
 	userGroup, ok := groups.(*[]string)
 	if !ok {
@@ -201,14 +252,20 @@ func GetUserDetails(c *gin.Context) (models.UserDetails, error) {
 		userBaseGroup = ""
 	}
 
+	userLabelScopes, ok := labelScopes.([]models.LabelScope)
+	if !ok {
+		userLabelScopes = nil
+	}
+
 	userDetails := models.UserDetails{
-		Groups:    *userGroup,
-		Role:      userRoleIs,
-		IsOwner:   userIsOwner,
-		UserID:    userID,
-		Projects:  userProjects,
-		UserName:  *user,
-		BaseGroup: userBaseGroup,
+		Groups:      *userGroup,
+		Role:        userRoleIs,
+		IsOwner:     userIsOwner,
+		UserID:      userID,
+		Projects:    userProjects,
+		UserName:    *user,
+		BaseGroup:   userBaseGroup,
+		LabelScopes: userLabelScopes,
 	}
 
 	return userDetails, nil
@@ -235,7 +292,49 @@ func checkRole(c *gin.Context, userDetail models.UserDetails) (err error) {
 }
 
 func (h *Handler) handleDepRequest(c *gin.Context, depFunc DepFunc) {
-	ctx := c.Request.Context()
+	requestDetails, ok := getDepRequestDetails(c)
+	if !ok {
+		return
+	}
+
+	response, err := depFunc(c.Request.Context(), requestDetails)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	if format := c.Query("format"); format != "" && format != dependency.FormatJSON {
+		body, contentType, err := dependency.RenderGraph(response, format, requestDetails.Version)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, contentType, []byte(body))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) handleReportRequest(c *gin.Context, reportFunc ReportFunc) {
+	requestDetails, ok := getDepRequestDetails(c)
+	if !ok {
+		return
+	}
+
+	response, err := reportFunc(c.Request.Context(), requestDetails)
+	if err != nil {
+		if writeConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func getDepRequestDetails(c *gin.Context) (models.RequestDetails, bool) {
 	userDetails, _ := GetUserDetails(c)
 
 	requestDetails := models.RequestDetails{
@@ -250,16 +349,51 @@ func (h *Handler) handleDepRequest(c *gin.Context, depFunc DepFunc) {
 	err := checkRole(c, userDetails)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
-		return
+		return requestDetails, false
 	}
 
-	response, err := depFunc(ctx, requestDetails)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
-		return
+	return requestDetails, true
+}
+
+// writeConflict answers stale updates with 409 and the version they lost against.
+func writeConflict(c *gin.Context, err error) bool {
+	var conflict *errstr.ConflictError
+	if !errors.As(err, &conflict) {
+		return false
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusConflict, gin.H{
+		"message":         err.Error(),
+		"current_version": conflict.CurrentVersion,
+		"updated_by":      conflict.UpdatedBy,
+	})
+	return true
+}
+
+// parseIfMatch accepts both strong and weak entity tags, quoted or bare. A wildcard
+// falls back to the version in the body.
+func parseIfMatch(header string) string {
+	value := strings.TrimSpace(header)
+	if value == "*" {
+		return ""
+	}
+	value = strings.TrimPrefix(value, "W/")
+	return strings.Trim(value, `"`)
+}
+
+func parseListOptions(c *gin.Context) models.ListOptions {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	listOptions := models.ListOptions{
+		Limit:  max(limit, 0),
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+	}
+	for _, field := range strings.Split(c.Query("fields"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			listOptions.Fields = append(listOptions.Fields, field)
+		}
+	}
+	return listOptions
 }
 
 func extractMetadata(c *gin.Context) map[string]string {
@@ -281,6 +415,14 @@ func getParamOrQuery(c *gin.Context, key string) string {
 	return c.Query(key)
 }
 
+// getSaveOrPublish drops the internal live mode, clients either save a draft or publish.
+func getSaveOrPublish(c *gin.Context) string {
+	if value := c.Query("save_or_publish"); value != models.SaveLive {
+		return value
+	}
+	return ""
+}
+
 func getOptionalParam(c *gin.Context, key string) string {
 	if value := c.Param(key); value != "" {
 		return value
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/bundle"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+func (h *Handler) ExportProject(c *gin.Context) {
+	requestDetails, ok := getDepRequestDetails(c)
+	if !ok {
+		return
+	}
+
+	includeSecretKeys := c.Query("include_secret_keys") == "true"
+	if includeSecretKeys && !requestDetails.User.IsOwner && requestDetails.User.Role != models.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"message": "only owners and admins can export secret keys"})
+		return
+	}
+
+	exported, err := h.Bundle.Export(c.Request.Context(), requestDetails, bundle.ExportOptions{
+		IncludeSecretKeys: includeSecretKeys,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	format := c.DefaultQuery("format", bundle.FormatJSON)
+	body, contentType, err := renderBundle(exported, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", requestDetails.Project, requestDetails.Version, format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, contentType, body)
+}
+
+func (h *Handler) PreviewImport(c *gin.Context) {
+	h.importProject(c, true, parseBundle)
+}
+
+func (h *Handler) ImportProject(c *gin.Context) {
+	h.importProject(c, false, parseBundle)
+}
+
+func (h *Handler) PreviewEnvoyImport(c *gin.Context) {
+	h.importProject(c, true, bundle.ParseEnvoy)
+}
+
+func (h *Handler) ImportEnvoy(c *gin.Context) {
+	h.importProject(c, false, bundle.ParseEnvoy)
+}
+
+func parseBundle(data []byte) (*bundle.Bundle, []string, error) {
+	parsed, err := bundle.Parse(data)
+	return parsed, nil, err
+}
+
+func (h *Handler) importProject(c *gin.Context, preview bool, parse func([]byte) (*bundle.Bundle, []string, error)) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		data, err := c.GetRawData()
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, warnings, err := parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := h.Bundle.Import(ctx, requestDetails, parsed, bundle.ImportOptions{
+			OnConflict:    c.Query("on_conflict"),
+			SaveOrPublish: getSaveOrPublish(c),
+		}, preview)
+		if err != nil {
+			if result != nil {
+				return nil, fmt.Errorf("import failed, nothing was written: %w", err)
+			}
+			return nil, err
+		}
+		result.Warnings = warnings
+		return result, nil
+	})
+}
+
+// renderBundle writes the bundle as json or yaml, yaml keys follow the json names.
+func renderBundle(exported *bundle.Bundle, format string) ([]byte, string, error) {
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch format {
+	case bundle.FormatJSON:
+		return data, "application/json", nil
+	case bundle.FormatYAML:
+		var plain any
+		if err := json.Unmarshal(data, &plain); err != nil {
+			return nil, "", err
+		}
+		body, err := yaml.Marshal(plain)
+		if err != nil {
+			return nil, "", err
+		}
+		return body, "application/yaml", nil
+	default:
+		return nil, "", fmt.Errorf("unknown format: %s", format)
+	}
+}
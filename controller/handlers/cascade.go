@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/cascade"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+func (h *Handler) PlanCascadeDelete(c *gin.Context) {
+	h.cascadeDelete(c, true)
+}
+
+func (h *Handler) CascadeDelete(c *gin.Context) {
+	h.cascadeDelete(c, false)
+}
+
+func (h *Handler) cascadeDelete(c *gin.Context, plan bool) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		var cascadeOptions cascade.Options
+		if err := c.ShouldBindJSON(&cascadeOptions); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if plan {
+			return h.Cascade.Plan(ctx, requestDetails, cascadeOptions)
+		}
+		return h.Cascade.Delete(ctx, requestDetails, cascadeOptions)
+	})
+}
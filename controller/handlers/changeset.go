@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/changeset"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+type openChangeSetBody struct {
+	Description string `json:"description"`
+}
+
+func (h *Handler) ListChangeSets(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.ChangeSet.ListChangeSets(ctx, requestDetails, c.Query("status"))
+	})
+}
+
+func (h *Handler) OpenChangeSet(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		var body openChangeSetBody
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&body); err != nil {
+				return nil, err
+			}
+		}
+		return h.ChangeSet.OpenChangeSet(ctx, requestDetails, body.Description)
+	})
+}
+
+func (h *Handler) GetChangeSet(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.ChangeSet.GetChangeSet(ctx, requestDetails, c.Param("changeset_id"))
+	})
+}
+
+func (h *Handler) DiscardChangeSet(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.ChangeSet.DiscardChangeSet(ctx, requestDetails, c.Param("changeset_id"))
+	})
+}
+
+func (h *Handler) AddChangeSetOperation(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		var operation changeset.Operation
+		if err := c.ShouldBindJSON(&operation); err != nil {
+			return nil, err
+		}
+		return h.ChangeSet.AddOperation(ctx, requestDetails, c.Param("changeset_id"), operation)
+	})
+}
+
+func (h *Handler) RemoveChangeSetOperation(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		index, err := strconv.Atoi(c.Param("index"))
+		if err != nil {
+			return nil, errors.New("invalid operation index")
+		}
+		return h.ChangeSet.RemoveOperation(ctx, requestDetails, c.Param("changeset_id"), index)
+	})
+}
+
+func (h *Handler) ValidateChangeSet(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.ChangeSet.ValidateChangeSet(ctx, requestDetails, c.Param("changeset_id"))
+	})
+}
+
+func (h *Handler) CommitChangeSet(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		requestDetails.SaveOrPublish = getSaveOrPublish(c)
+		return h.ChangeSet.CommitChangeSet(ctx, requestDetails, c.Param("changeset_id"))
+	})
+}
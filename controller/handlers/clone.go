@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/clone"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+func (h *Handler) PreviewClone(c *gin.Context) {
+	h.cloneResource(c, true)
+}
+
+func (h *Handler) CloneResource(c *gin.Context) {
+	h.cloneResource(c, false)
+}
+
+func (h *Handler) cloneResource(c *gin.Context, preview bool) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		var cloneOptions clone.Options
+		if err := c.ShouldBindJSON(&cloneOptions); err != nil {
+			return nil, err
+		}
+		cloneOptions.SaveOrPublish = getSaveOrPublish(c)
+		return h.Clone.Clone(ctx, requestDetails, cloneOptions, preview)
+	})
+}
@@ -7,3 +7,19 @@ import (
 func (h *Handler) GetResourceDependencies(c *gin.Context) {
 	h.handleDepRequest(c, h.dependency.GetResourceDependencies)
 }
+
+func (h *Handler) ExportProjectDependencies(c *gin.Context) {
+	h.handleDepRequest(c, h.dependency.ExportProjectDependencies)
+}
+
+func (h *Handler) GetResourceImpact(c *gin.Context) {
+	h.handleReportRequest(c, h.dependency.GetResourceImpact)
+}
+
+func (h *Handler) GetOrphanReport(c *gin.Context) {
+	h.handleReportRequest(c, h.dependency.GetOrphanReport)
+}
+
+func (h *Handler) DeleteOrphans(c *gin.Context) {
+	h.handleReportRequest(c, h.dependency.DeleteOrphans)
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+type publishDraftsBody struct {
+	IDs []string `json:"ids"`
+}
+
+func (h *Handler) ListDrafts(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Draft.ListDrafts(ctx, requestDetails)
+	})
+}
+
+func (h *Handler) GetDraft(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Draft.GetDraft(ctx, requestDetails, c.Param("draft_id"))
+	})
+}
+
+func (h *Handler) DiscardDraft(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Draft.DiscardDraft(ctx, requestDetails, c.Param("draft_id"))
+	})
+}
+
+func (h *Handler) PublishDrafts(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		var body publishDraftsBody
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&body); err != nil {
+				return nil, err
+			}
+		}
+		return h.Draft.PublishDrafts(ctx, requestDetails, body.IDs)
+	})
+}
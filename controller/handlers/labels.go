@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/relabel"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+func (h *Handler) SetLabels(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		var change relabel.Change
+		if err := c.ShouldBindJSON(&change); err != nil {
+			return nil, err
+		}
+		return h.Relabel.Relabel(ctx, requestDetails, change)
+	})
+}
+
+func (h *Handler) ListLabels(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Relabel.Keys(ctx, requestDetails)
+	})
+}
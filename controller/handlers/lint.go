@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+func (h *Handler) GetLintRules(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Lint.Rules(ctx, requestDetails)
+	})
+}
+
+func (h *Handler) LintResource(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Lint.Resource(ctx, requestDetails)
+	})
+}
+
+func (h *Handler) LintProject(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Lint.Project(ctx, requestDetails)
+	})
+}
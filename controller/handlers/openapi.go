@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"reflect"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/api/auth"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/bundle"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/cascade"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/changeset"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/clone"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/draft"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/lints"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/relabel"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/rename"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/revision"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/search"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/trashbin"
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/upgrade"
+	"github.com/CloudNativeWorks/elchi-backend/controller/dependency"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/drafts"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/revisions"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/schema"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/trash"
+)
+
+var listQuery = []string{"limit", "cursor", "sort", "fields"}
+
+// withList adds the query parameters of paged lists.
+func withList(names ...string) []string {
+	return append(names, listQuery...)
+}
+
+var (
+	resourceType  = reflect.TypeFor[models.DBResource]()
+	changeSetType = reflect.TypeFor[changeset.ChangeSet]()
+	graphType     = reflect.TypeFor[dependency.Graph]()
+	bundleType    = reflect.TypeFor[bundle.Bundle]()
+	importType    = reflect.TypeFor[bundle.ImportResult]()
+	cloneType     = reflect.TypeFor[clone.Options]()
+	cascadeType   = reflect.TypeFor[cascade.Options]()
+	userType      = reflect.TypeFor[models.User]()
+)
+
+// operations describes what the handlers read and write for the OpenAPI document, by
+// handler name. Bodies without a fixed shape, such as paged lists, are left out.
+var operations = map[string]schema.Operation{
+	"ListResource":   {Query: withList("project", "gtype", "selector")},
+	"GetResource":    {Query: []string{"project", "resource_id"}, Response: resourceType},
+	"SetResource":    {Query: []string{"project", "save_or_publish"}, Request: resourceType},
+	"UpdateResource": {Query: []string{"project", "resource_id", "save_or_publish"}, Request: resourceType, IfMatch: true},
+	"DelResource":    {Query: []string{"project", "version", "gtype", "resource_id", "permanent"}},
+
+	"GetExtensions":         {Query: []string{"project"}},
+	"ListExtensions":        {Query: withList("project")},
+	"GetExtension":          {Query: []string{"project"}, Response: resourceType},
+	"GetOtherExtension":     {Query: []string{"project", "version"}, Response: resourceType},
+	"SetExtension":          {Query: []string{"project", "save_or_publish"}, Request: resourceType},
+	"UpdateExtension":       {Query: []string{"project", "resource_id", "save_or_publish"}, Request: resourceType, IfMatch: true},
+	"UpdateOtherExtensions": {Query: []string{"project", "resource_id", "save_or_publish"}, Request: resourceType, IfMatch: true},
+	"DelExtension":          {Query: []string{"project", "version", "gtype", "resource_id", "permanent"}},
+
+	"GetCustomResourceList":   {Query: withList("project", "version", "collection", "gtype", "category", "canonical_name", "selector", "for_metrics", "metadata_non_eds_cluster")},
+	"GetCustomHTTPFilterList": {Query: withList("project", "version", "collection", "category", "selector", "metadata_http_filter")},
+	"GetResourceCounts":       {Query: []string{"project", "selector"}},
+	"GetFilterCounts":         {Query: []string{"project", "collection", "category"}},
+
+	"GetScenario": {Query: []string{"metadata_scenario_id"}},
+	"SetScenario": {Query: []string{"project", "version", "metadata_scenario_id"}, Request: reflect.TypeFor[models.ScenarioBody]()},
+
+	"GetResourceDependencies":   {Query: []string{"project", "version", "collection", "gtype", "format"}, Response: graphType},
+	"ExportProjectDependencies": {Query: []string{"project", "version", "format"}, Response: graphType},
+	"GetResourceImpact":         {Query: []string{"project", "version", "collection", "gtype"}, Response: reflect.TypeFor[dependency.Impact]()},
+	"GetOrphanReport":           {Query: []string{"project", "version"}, Response: reflect.TypeFor[dependency.OrphanReport]()},
+	"DeleteOrphans":             {Query: []string{"project", "version", "collection"}, Response: reflect.TypeFor[dependency.OrphanDeleteResult]()},
+
+	"ListRevisions":    {Query: []string{"project", "version", "collection", "name"}, Response: reflect.TypeFor[[]revisions.Revision]()},
+	"GetRevision":      {Query: []string{"project", "collection"}, Response: reflect.TypeFor[revisions.Revision]()},
+	"DiffRevisions":    {Query: []string{"project", "collection", "from", "to"}, Response: reflect.TypeFor[revision.RevisionDiff]()},
+	"RollbackRevision": {Query: []string{"project", "collection", "save_or_publish"}},
+
+	"ListDrafts":    {Query: []string{"project", "version", "collection"}, Response: reflect.TypeFor[[]drafts.Draft]()},
+	"GetDraft":      {Query: []string{"project"}, Response: reflect.TypeFor[draft.DraftDetails]()},
+	"DiscardDraft":  {Query: []string{"project"}},
+	"PublishDrafts": {Query: []string{"project"}, Request: reflect.TypeFor[publishDraftsBody](), Response: reflect.TypeFor[draft.PublishResult]()},
+
+	"ListChangeSets":           {Query: []string{"project", "status"}, Response: reflect.TypeFor[[]changeset.ChangeSet]()},
+	"OpenChangeSet":            {Query: []string{"project"}, Request: reflect.TypeFor[openChangeSetBody](), Response: changeSetType},
+	"GetChangeSet":             {Query: []string{"project"}, Response: changeSetType},
+	"DiscardChangeSet":         {Query: []string{"project"}, Response: changeSetType},
+	"AddChangeSetOperation":    {Query: []string{"project"}, Request: reflect.TypeFor[changeset.Operation](), Response: changeSetType},
+	"RemoveChangeSetOperation": {Query: []string{"project"}, Response: changeSetType},
+	"ValidateChangeSet":        {Query: []string{"project"}, Response: reflect.TypeFor[changeset.CommitResult]()},
+	"CommitChangeSet":          {Query: []string{"project", "save_or_publish"}, Response: reflect.TypeFor[changeset.CommitResult]()},
+
+	"ExportProject":      {Query: []string{"project", "version", "format", "include_secret_keys"}, Response: bundleType},
+	"ImportProject":      {Query: []string{"project", "version", "on_conflict", "save_or_publish"}, Request: bundleType, Response: importType},
+	"PreviewImport":      {Query: []string{"project", "version", "on_conflict"}, Request: bundleType, Response: importType},
+	"ImportEnvoy":        {Query: []string{"project", "version", "on_conflict", "save_or_publish"}, Request: reflect.TypeFor[map[string]any](), Response: importType},
+	"PreviewEnvoyImport": {Query: []string{"project", "version", "on_conflict"}, Request: reflect.TypeFor[map[string]any](), Response: importType},
+
+	"UpgradeProject": {Query: []string{"project", "from", "to", "on_conflict", "save_or_publish"}, Response: reflect.TypeFor[upgrade.Plan]()},
+	"PlanUpgrade":    {Query: []string{"project", "from", "to", "on_conflict"}, Response: reflect.TypeFor[upgrade.Plan]()},
+
+	"RenameResource": {Query: []string{"project", "version", "collection"}, Request: reflect.TypeFor[renameBody](), Response: reflect.TypeFor[rename.Result]()},
+	"CloneResource":  {Query: []string{"project", "version", "collection", "save_or_publish"}, Request: cloneType, Response: reflect.TypeFor[clone.Result]()},
+	"PreviewClone":   {Query: []string{"project", "version", "collection"}, Request: cloneType, Response: reflect.TypeFor[clone.Result]()},
+
+	"SearchResources": {Query: []string{"project", "version", "collection", "limit", "cursor"}, Request: reflect.TypeFor[search.Query](), Response: reflect.TypeFor[search.Page]()},
+
+	"ListLabels": {Query: []string{"project", "version", "collection"}, Response: reflect.TypeFor[map[string][]string]()},
+	"SetLabels":  {Query: []string{"project", "version", "collection"}, Request: reflect.TypeFor[relabel.Change](), Response: reflect.TypeFor[relabel.Result]()},
+
+	"ListTrash":    {Query: []string{"project", "version", "collection", "name"}, Response: reflect.TypeFor[[]trash.Entry]()},
+	"RestoreTrash": {Query: []string{"project"}, Response: reflect.TypeFor[trashbin.Result]()},
+	"PurgeTrash":   {Query: []string{"project"}},
+
+	"PlanCascadeDelete": {Query: []string{"project", "version", "collection"}, Request: cascadeType, Response: reflect.TypeFor[cascade.Plan]()},
+	"CascadeDelete":     {Query: []string{"project", "version", "collection"}, Request: cascadeType, Response: reflect.TypeFor[cascade.Result]()},
+
+	"GetGTypeSchema": {Query: []string{"gtype"}},
+
+	"GetLintRules": {Query: []string{"project"}, Response: reflect.TypeFor[[]lints.RuleInfo]()},
+	"LintResource": {Query: []string{"project", "version", "collection"}, Response: reflect.TypeFor[lints.Report]()},
+	"LintProject":  {Query: []string{"project", "version", "collection", "gtype"}, Response: reflect.TypeFor[lints.ProjectReport]()},
+
+	"ListClients":  {Query: withList("with_service_ips")},
+	"Commands":     {Request: reflect.TypeFor[models.Operations]()},
+	"ListServices": {Query: listQuery},
+	"GetService":   {Query: []string{"from_client", "client_id"}},
+
+	"Login":         {Request: userType, Response: userType},
+	"Refresh":       {Request: userType},
+	"ListUsers":     {Query: []string{"project", "isProjectPage"}},
+	"GetUser":       {Query: []string{"project", "isProjectPage"}},
+	"SetUpdateUser": {Query: []string{"project", "isProjectPage"}, Request: reflect.TypeFor[auth.UserWithGroups]()},
+	"DeleteUser":    {Query: []string{"project"}},
+
+	"ListGroups":     {Query: []string{"project"}},
+	"GetGroup":       {Query: []string{"project"}},
+	"SetUpdateGroup": {Query: []string{"project"}, Request: reflect.TypeFor[auth.GroupWithActiveStatus]()},
+	"DeleteGroup":    {Query: []string{"project"}},
+
+	"SetUpdateProject": {Request: reflect.TypeFor[auth.ProjectWithActiveStatus]()},
+	"GetPermissions":   {Query: []string{"project", "selector"}},
+}
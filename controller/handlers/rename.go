@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+type renameBody struct {
+	NewName string `json:"new_name"`
+}
+
+func (h *Handler) RenameResource(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		var body renameBody
+		if err := c.ShouldBindJSON(&body); err != nil {
+			return nil, err
+		}
+		return h.Rename.Rename(ctx, requestDetails, body.NewName)
+	})
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+func (h *Handler) ListRevisions(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		requestDetails.Name = c.Query("name")
+		return h.Revision.ListRevisions(ctx, requestDetails)
+	})
+}
+
+func (h *Handler) GetRevision(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Revision.GetRevision(ctx, requestDetails, c.Param("revision_id"))
+	})
+}
+
+func (h *Handler) DiffRevisions(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Revision.DiffRevisions(ctx, requestDetails, c.Query("from"), c.Query("to"))
+	})
+}
+
+func (h *Handler) RollbackRevision(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		requestDetails.SaveOrPublish = getSaveOrPublish(c)
+		return h.Revision.Rollback(ctx, requestDetails, c.Param("revision_id"))
+	})
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/schema"
+)
+
+func (h *Handler) GetGTypeSchema(c *gin.Context) {
+	h.handleReportRequest(c, func(_ context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Schema.GType(requestDetails)
+	})
+}
+
+// GetOpenAPI describes the routes registered on the engine.
+func (h *Handler) GetOpenAPI(e *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.handleReportRequest(c, func(_ context.Context, _ models.RequestDetails) (any, error) {
+			engineRoutes := e.Routes()
+			routes := make([]schema.Route, 0, len(engineRoutes))
+			for _, route := range engineRoutes {
+				routes = append(routes, schema.Route{Method: route.Method, Path: route.Path, Handler: route.Handler})
+			}
+			return h.Schema.OpenAPI(routes, operations), nil
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/search"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+func (h *Handler) SearchResources(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		var query search.Query
+		if err := c.ShouldBindJSON(&query); err != nil {
+			return nil, err
+		}
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		return h.Search.Search(ctx, requestDetails, query, limit, c.Query("cursor"))
+	})
+}
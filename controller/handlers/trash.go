@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+func (h *Handler) ListTrash(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		requestDetails.Name = c.Query("name")
+		return h.Trash.List(ctx, requestDetails)
+	})
+}
+
+func (h *Handler) RestoreTrash(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Trash.Restore(ctx, requestDetails, c.Param("id"))
+	})
+}
+
+func (h *Handler) PurgeTrash(c *gin.Context) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Trash.Purge(ctx, requestDetails, c.Param("id"))
+	})
+}
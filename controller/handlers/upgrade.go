@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/upgrade"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+func (h *Handler) PlanUpgrade(c *gin.Context) {
+	h.upgradeProject(c, true)
+}
+
+func (h *Handler) UpgradeProject(c *gin.Context) {
+	h.upgradeProject(c, false)
+}
+
+func (h *Handler) upgradeProject(c *gin.Context, preview bool) {
+	h.handleReportRequest(c, func(ctx context.Context, requestDetails models.RequestDetails) (any, error) {
+		return h.Upgrade.Upgrade(ctx, requestDetails, upgrade.Options{
+			From:          c.Query("from"),
+			To:            c.Query("to"),
+			OnConflict:    c.Query("on_conflict"),
+			SaveOrPublish: getSaveOrPublish(c),
+		}, preview)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	bridgeClient "github.com/CloudNativeWorks/elchi-backend/controller/bridge"
@@ -12,6 +13,7 @@ import (
 	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models/downstreamfilters"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/resources"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/services"
 )
@@ -20,6 +22,15 @@ type Processed struct {
 	ProcessedResources []string
 	Listeners          []string
 	Depends            []string
+	Nodes              []NodeChanges
+}
+
+type NodeChanges struct {
+	NodeID            string                    `json:"node_id"`
+	DownstreamAddress string                    `json:"downstream_address,omitempty"`
+	Version           string                    `json:"version"`
+	Changes           []*bridge.ResourceChanges `json:"changes"`
+	Error             string                    `json:"error,omitempty"`
 }
 
 func DetectChangedResource(ctx context.Context, gType models.GTypes, version, resourceName, project string, context *db.AppContext, processed *Processed, poke *bridge.PokeServiceClient, managed bool) *Processed {
@@ -53,17 +64,47 @@ func DetectChangedResource(ctx context.Context, gType models.GTypes, version, re
 }
 
 func HandlePoke(ctx context.Context, context *db.AppContext, resourceName, project, version string, processed *Processed, poke *bridge.PokeServiceClient, downstreamAddress string) {
-	_, err := bridgeClient.PokeNode(ctx, *poke, resourceName, project, version, downstreamAddress)
+	nodeChanges := NodeChanges{
+		NodeID:            resourceName + "::" + project,
+		DownstreamAddress: downstreamAddress,
+		Changes:           []*bridge.ResourceChanges{},
+	}
+
+	resp, err := bridgeClient.PokeNode(ctx, *poke, resourceName, project, version, downstreamAddress)
 	if err != nil {
 		context.Logger.Debugf("Poke failed: %s\n", err)
+		nodeChanges.Error = err.Error()
+	} else {
+		nodeChanges.Version = resp.GetVersion()
+		if changes := resp.GetChanges(); changes != nil {
+			nodeChanges.Changes = changes
+		}
 	}
 
+	processed.Nodes = append(processed.Nodes, nodeChanges)
+
 	processed.Listeners = append(processed.Listeners, resourceName)
 	result := strings.Join(processed.Depends, " \n ")
 	context.Logger.Infof("new version added to snapshot for (%s) processed resource paths: \n %s", resourceName, result)
 }
 
 func ProcessResource(ctx context.Context, context *db.AppContext, gType models.GTypes, version, resourceName, project string, processed *Processed, poke *bridge.PokeServiceClient) {
+	if references.Ready(ctx, context.Client) {
+		referrers, err := references.Referrers(ctx, context.Client, project, version, gType.CollectionString(), resourceName)
+		if err == nil {
+			for collection, names := range references.GroupByCollection(referrers) {
+				filter := primitive.D{
+					{Key: "general.name", Value: bson.M{"$in": names}},
+					{Key: "general.project", Value: project},
+					{Key: "general.version", Value: version},
+				}
+				CheckResource(ctx, context, filter, collection, project, version, processed, poke)
+			}
+			return
+		}
+		context.Logger.Debugf("Error finding referrers, falling back to downstream filters: %v", err)
+	}
+
 	dfm := downstreamfilters.DownstreamFilter{
 		Name:    resourceName,
 		Project: project,
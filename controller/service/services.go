@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/pagination"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -21,8 +22,28 @@ type ServiceWithStatus struct {
 	Status string `json:"status"`
 }
 
+// serviceListSpec is the list spec of services, status is the status of the first
+// envoy of the service.
+var serviceListSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"id":         "_id",
+		"name":       "name",
+		"project":    "project",
+		"admin_port": "admin_port",
+	},
+	DefaultSort: "name",
+	Fields: map[string]string{
+		"name":       "name",
+		"project":    "project",
+		"admin_port": "admin_port",
+		"clients":    "clients",
+		"status":     "status",
+	},
+	Keep: []string{"name"},
+}
+
 func (s *AppHandler) ListServices(ctx context.Context, _ models.OperationClass, requestDetails models.RequestDetails) (any, error) {
-	pipeline := bson.A{
+	stages := bson.A{
 		bson.D{
 			{Key: "$lookup", Value: bson.D{
 				{Key: "from", Value: "envoys"},
@@ -60,18 +81,13 @@ func (s *AppHandler) ListServices(ctx context.Context, _ models.OperationClass,
 		},
 	}
 
-	cursor, err := s.Context.Client.Collection("services").Aggregate(ctx, pipeline)
+	listed, err := pagination.Aggregate(ctx, s.Context.Client.Collection("services"), bson.M{}, requestDetails.List, serviceListSpec, stages)
 	if err != nil {
-		return nil, fmt.Errorf("failed to aggregate services: %v", err)
+		return nil, fmt.Errorf("failed to aggregate services: %w", err)
 	}
-	defer cursor.Close(ctx)
 
-	var result []ServiceWithStatus
-	for cursor.Next(ctx) {
-		var svc bson.M
-		if err := cursor.Decode(&svc); err != nil {
-			return nil, fmt.Errorf("failed to decode service: %v", err)
-		}
+	result := make([]ServiceWithStatus, 0, len(listed.Records))
+	for _, svc := range listed.Records {
 		var service Service
 		bsonBytes, _ := bson.Marshal(svc)
 		_ = bson.Unmarshal(bsonBytes, &service)
@@ -81,7 +97,7 @@ func (s *AppHandler) ListServices(ctx context.Context, _ models.OperationClass,
 			Status:  status,
 		})
 	}
-	return result, nil
+	return listed.Wrap(result), nil
 }
 
 /* func (s *AppHandler) ListServicess(ctx context.Context, _ models.OperationClass, requestDetails models.RequestDetails) (any, error) {
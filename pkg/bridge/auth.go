@@ -0,0 +1,156 @@
+package bridge
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/config"
+)
+
+const (
+	TokenMetadataKey = "bridge-token"
+	ServicePrefix    = "/bridge."
+	tokenMaxSkew     = 5 * time.Minute
+)
+
+// SignToken returns "<unix>.<hmac>" where the hmac covers the timestamp,
+// so a captured token is only usable inside the allowed clock skew.
+func SignToken(secret string, now time.Time) string {
+	ts := strconv.FormatInt(now.Unix(), 10)
+	return ts + "." + tokenSignature(secret, ts)
+}
+
+func VerifyToken(secret, token string, now time.Time) error {
+	ts, signature, found := strings.Cut(token, ".")
+	if !found {
+		return errors.New("malformed bridge token")
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errors.New("malformed bridge token timestamp")
+	}
+
+	skew := now.Sub(time.Unix(unix, 0))
+	if skew > tokenMaxSkew || skew < -tokenMaxSkew {
+		return errors.New("bridge token expired")
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(tokenSignature(secret, ts))) {
+		return errors.New("invalid bridge token signature")
+	}
+
+	return nil
+}
+
+func tokenSignature(secret, ts string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type tokenCredentials struct {
+	secret string
+	secure bool
+}
+
+// NewTokenCredentials signs a fresh token for every bridge call.
+func NewTokenCredentials(secret string, secure bool) credentials.PerRPCCredentials {
+	return &tokenCredentials{secret: secret, secure: secure}
+}
+
+func (t *tokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{TokenMetadataKey: SignToken(t.secret, time.Now())}, nil
+}
+
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+	return t.secure
+}
+
+// ClientTLSConfig builds the controller side tls config from the bridge CA and client certificate.
+// It returns nil when neither is configured.
+func ClientTLSConfig(cfg *config.AppConfig) (*tls.Config, error) {
+	if cfg.ElchiBridgeCAFile == "" && cfg.ElchiBridgeCertFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.ElchiBridgeCAFile != "" {
+		pool, err := loadCertPool(cfg.ElchiBridgeCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ElchiBridgeCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ElchiBridgeCertFile, cfg.ElchiBridgeKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load bridge client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// ServerTLSConfig builds the control plane side tls config of the bridge listener.
+// When a CA is configured, client certificates signed by it are required. It returns nil
+// when no certificate is configured, a CA without a certificate is a config error since
+// client certificates cannot be verified without tls.
+func ServerTLSConfig(cfg *config.AppConfig) (*tls.Config, error) {
+	if cfg.ElchiBridgeCertFile == "" {
+		if cfg.ElchiBridgeCAFile != "" {
+			return nil, errors.New("ELCHI_BRIDGE_CA_FILE requires ELCHI_BRIDGE_CERT_FILE and ELCHI_BRIDGE_KEY_FILE on the control plane")
+		}
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.ElchiBridgeCertFile, cfg.ElchiBridgeKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load bridge server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if cfg.ElchiBridgeCAFile != "" {
+		pool, err := loadCertPool(cfg.ElchiBridgeCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("read bridge ca file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("bridge ca file contains no valid certificates")
+	}
+
+	return pool, nil
+}
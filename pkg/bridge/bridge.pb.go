@@ -338,17 +338,88 @@ func (x *PokeRequest) GetDownstreamAddress() string {
 	return ""
 }
 
+type ResourceChanges struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Added    []string `protobuf:"bytes,2,rep,name=added,proto3" json:"added,omitempty"`
+	Modified []string `protobuf:"bytes,3,rep,name=modified,proto3" json:"modified,omitempty"`
+	Removed  []string `protobuf:"bytes,4,rep,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *ResourceChanges) Reset() {
+	*x = ResourceChanges{}
+	mi := &file_bridge_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceChanges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceChanges) ProtoMessage() {}
+
+func (x *ResourceChanges) ProtoReflect() protoreflect.Message {
+	mi := &file_bridge_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceChanges.ProtoReflect.Descriptor instead.
+func (*ResourceChanges) Descriptor() ([]byte, []int) {
+	return file_bridge_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ResourceChanges) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ResourceChanges) GetAdded() []string {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *ResourceChanges) GetModified() []string {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+func (x *ResourceChanges) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
 type PokeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Message string             `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Version string             `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Changes []*ResourceChanges `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
 }
 
 func (x *PokeResponse) Reset() {
 	*x = PokeResponse{}
-	mi := &file_bridge_proto_msgTypes[4]
+	mi := &file_bridge_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -360,7 +431,7 @@ func (x *PokeResponse) String() string {
 func (*PokeResponse) ProtoMessage() {}
 
 func (x *PokeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[4]
+	mi := &file_bridge_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +444,7 @@ func (x *PokeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PokeResponse.ProtoReflect.Descriptor instead.
 func (*PokeResponse) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{4}
+	return file_bridge_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PokeResponse) GetMessage() string {
@@ -383,6 +454,20 @@ func (x *PokeResponse) GetMessage() string {
 	return ""
 }
 
+func (x *PokeResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PokeResponse) GetChanges() []*ResourceChanges {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
 type Empty struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -391,7 +476,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_bridge_proto_msgTypes[5]
+	mi := &file_bridge_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -403,7 +488,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[5]
+	mi := &file_bridge_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,7 +501,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{5}
+	return file_bridge_proto_rawDescGZIP(), []int{6}
 }
 
 type SnapshotKey struct {
@@ -429,7 +514,7 @@ type SnapshotKey struct {
 
 func (x *SnapshotKey) Reset() {
 	*x = SnapshotKey{}
-	mi := &file_bridge_proto_msgTypes[6]
+	mi := &file_bridge_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -441,7 +526,7 @@ func (x *SnapshotKey) String() string {
 func (*SnapshotKey) ProtoMessage() {}
 
 func (x *SnapshotKey) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[6]
+	mi := &file_bridge_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -454,7 +539,7 @@ func (x *SnapshotKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SnapshotKey.ProtoReflect.Descriptor instead.
 func (*SnapshotKey) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{6}
+	return file_bridge_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SnapshotKey) GetKey() string {
@@ -474,7 +559,7 @@ type SnapshotKeyList struct {
 
 func (x *SnapshotKeyList) Reset() {
 	*x = SnapshotKeyList{}
-	mi := &file_bridge_proto_msgTypes[7]
+	mi := &file_bridge_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -486,7 +571,7 @@ func (x *SnapshotKeyList) String() string {
 func (*SnapshotKeyList) ProtoMessage() {}
 
 func (x *SnapshotKeyList) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[7]
+	mi := &file_bridge_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,7 +584,7 @@ func (x *SnapshotKeyList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SnapshotKeyList.ProtoReflect.Descriptor instead.
 func (*SnapshotKeyList) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{7}
+	return file_bridge_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SnapshotKeyList) GetKeys() []string {
@@ -520,7 +605,7 @@ type SnapshotResource struct {
 
 func (x *SnapshotResource) Reset() {
 	*x = SnapshotResource{}
-	mi := &file_bridge_proto_msgTypes[8]
+	mi := &file_bridge_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -532,7 +617,7 @@ func (x *SnapshotResource) String() string {
 func (*SnapshotResource) ProtoMessage() {}
 
 func (x *SnapshotResource) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[8]
+	mi := &file_bridge_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -545,7 +630,7 @@ func (x *SnapshotResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SnapshotResource.ProtoReflect.Descriptor instead.
 func (*SnapshotResource) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{8}
+	return file_bridge_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SnapshotResource) GetType() string {
@@ -574,7 +659,7 @@ type SnapshotResourceList struct {
 
 func (x *SnapshotResourceList) Reset() {
 	*x = SnapshotResourceList{}
-	mi := &file_bridge_proto_msgTypes[9]
+	mi := &file_bridge_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -586,7 +671,7 @@ func (x *SnapshotResourceList) String() string {
 func (*SnapshotResourceList) ProtoMessage() {}
 
 func (x *SnapshotResourceList) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[9]
+	mi := &file_bridge_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -599,7 +684,7 @@ func (x *SnapshotResourceList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SnapshotResourceList.ProtoReflect.Descriptor instead.
 func (*SnapshotResourceList) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{9}
+	return file_bridge_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SnapshotResourceList) GetResources() []*SnapshotResource {
@@ -634,7 +719,7 @@ type ValidateResourceRequest struct {
 
 func (x *ValidateResourceRequest) Reset() {
 	*x = ValidateResourceRequest{}
-	mi := &file_bridge_proto_msgTypes[10]
+	mi := &file_bridge_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -646,7 +731,7 @@ func (x *ValidateResourceRequest) String() string {
 func (*ValidateResourceRequest) ProtoMessage() {}
 
 func (x *ValidateResourceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[10]
+	mi := &file_bridge_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -659,7 +744,7 @@ func (x *ValidateResourceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateResourceRequest.ProtoReflect.Descriptor instead.
 func (*ValidateResourceRequest) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{10}
+	return file_bridge_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ValidateResourceRequest) GetGtype() string {
@@ -686,7 +771,7 @@ type ValidateResourceResponse struct {
 
 func (x *ValidateResourceResponse) Reset() {
 	*x = ValidateResourceResponse{}
-	mi := &file_bridge_proto_msgTypes[11]
+	mi := &file_bridge_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -698,7 +783,7 @@ func (x *ValidateResourceResponse) String() string {
 func (*ValidateResourceResponse) ProtoMessage() {}
 
 func (x *ValidateResourceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[11]
+	mi := &file_bridge_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -711,7 +796,7 @@ func (x *ValidateResourceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateResourceResponse.ProtoReflect.Descriptor instead.
 func (*ValidateResourceResponse) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{11}
+	return file_bridge_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ValidateResourceResponse) GetError() string {
@@ -776,10 +861,22 @@ var file_bridge_proto_rawDesc = []byte{
 	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x6f, 0x77,
 	0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
 	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x28, 0x0a, 0x0c, 0x50, 0x6f, 0x6b, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1f, 0x0a, 0x0b, 0x53,
+	0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x71, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0x75, 0x0a, 0x0c, 0x50,
+	0x6f, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x31, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1f, 0x0a, 0x0b, 0x53,
 	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
 	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x25, 0x0a, 0x0f,
 	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12,
@@ -844,42 +941,44 @@ func file_bridge_proto_rawDescGZIP() []byte {
 	return file_bridge_proto_rawDescData
 }
 
-var file_bridge_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_bridge_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_bridge_proto_goTypes = []any{
 	(*Client)(nil),                   // 0: bridge.Client
 	(*ErrorEntry)(nil),               // 1: bridge.ErrorEntry
 	(*NodeErrorResponse)(nil),        // 2: bridge.NodeErrorResponse
 	(*PokeRequest)(nil),              // 3: bridge.PokeRequest
-	(*PokeResponse)(nil),             // 4: bridge.PokeResponse
-	(*Empty)(nil),                    // 5: bridge.Empty
-	(*SnapshotKey)(nil),              // 6: bridge.SnapshotKey
-	(*SnapshotKeyList)(nil),          // 7: bridge.SnapshotKeyList
-	(*SnapshotResource)(nil),         // 8: bridge.SnapshotResource
-	(*SnapshotResourceList)(nil),     // 9: bridge.SnapshotResourceList
-	(*ValidateResourceRequest)(nil),  // 10: bridge.ValidateResourceRequest
-	(*ValidateResourceResponse)(nil), // 11: bridge.ValidateResourceResponse
-	(*structpb.Struct)(nil),          // 12: google.protobuf.Struct
-	(*anypb.Any)(nil),                // 13: google.protobuf.Any
+	(*ResourceChanges)(nil),          // 4: bridge.ResourceChanges
+	(*PokeResponse)(nil),             // 5: bridge.PokeResponse
+	(*Empty)(nil),                    // 6: bridge.Empty
+	(*SnapshotKey)(nil),              // 7: bridge.SnapshotKey
+	(*SnapshotKeyList)(nil),          // 8: bridge.SnapshotKeyList
+	(*SnapshotResource)(nil),         // 9: bridge.SnapshotResource
+	(*SnapshotResourceList)(nil),     // 10: bridge.SnapshotResourceList
+	(*ValidateResourceRequest)(nil),  // 11: bridge.ValidateResourceRequest
+	(*ValidateResourceResponse)(nil), // 12: bridge.ValidateResourceResponse
+	(*structpb.Struct)(nil),          // 13: google.protobuf.Struct
+	(*anypb.Any)(nil),                // 14: google.protobuf.Any
 }
 var file_bridge_proto_depIdxs = []int32{
 	1,  // 0: bridge.Client.errors:type_name -> bridge.ErrorEntry
 	1,  // 1: bridge.NodeErrorResponse.errors:type_name -> bridge.ErrorEntry
-	12, // 2: bridge.SnapshotResource.data:type_name -> google.protobuf.Struct
-	8,  // 3: bridge.SnapshotResourceList.resources:type_name -> bridge.SnapshotResource
-	13, // 4: bridge.ValidateResourceRequest.resource:type_name -> google.protobuf.Any
-	5,  // 5: bridge.SnapshotService.GetSnapshotKeys:input_type -> bridge.Empty
-	6,  // 6: bridge.SnapshotService.GetSnapshotResources:input_type -> bridge.SnapshotKey
-	3,  // 7: bridge.PokeService.Poke:input_type -> bridge.PokeRequest
-	10, // 8: bridge.ResourceService.ValidateResource:input_type -> bridge.ValidateResourceRequest
-	7,  // 9: bridge.SnapshotService.GetSnapshotKeys:output_type -> bridge.SnapshotKeyList
-	9,  // 10: bridge.SnapshotService.GetSnapshotResources:output_type -> bridge.SnapshotResourceList
-	4,  // 11: bridge.PokeService.Poke:output_type -> bridge.PokeResponse
-	11, // 12: bridge.ResourceService.ValidateResource:output_type -> bridge.ValidateResourceResponse
-	9,  // [9:13] is the sub-list for method output_type
-	5,  // [5:9] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	4,  // 2: bridge.PokeResponse.changes:type_name -> bridge.ResourceChanges
+	13, // 3: bridge.SnapshotResource.data:type_name -> google.protobuf.Struct
+	9,  // 4: bridge.SnapshotResourceList.resources:type_name -> bridge.SnapshotResource
+	14, // 5: bridge.ValidateResourceRequest.resource:type_name -> google.protobuf.Any
+	6,  // 6: bridge.SnapshotService.GetSnapshotKeys:input_type -> bridge.Empty
+	7,  // 7: bridge.SnapshotService.GetSnapshotResources:input_type -> bridge.SnapshotKey
+	3,  // 8: bridge.PokeService.Poke:input_type -> bridge.PokeRequest
+	11, // 9: bridge.ResourceService.ValidateResource:input_type -> bridge.ValidateResourceRequest
+	8,  // 10: bridge.SnapshotService.GetSnapshotKeys:output_type -> bridge.SnapshotKeyList
+	10, // 11: bridge.SnapshotService.GetSnapshotResources:output_type -> bridge.SnapshotResourceList
+	5,  // 12: bridge.PokeService.Poke:output_type -> bridge.PokeResponse
+	12, // 13: bridge.ResourceService.ValidateResource:output_type -> bridge.ValidateResourceResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_bridge_proto_init() }
@@ -893,7 +992,7 @@ func file_bridge_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_bridge_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
@@ -23,7 +23,14 @@ func ipv4Dialer(ctx context.Context, addr string) (net.Conn, error) {
 func NewGRPCClient(appCtx *db.AppContext) (*grpc.ClientConn, error) {
 	var transportCredentials credentials.TransportCredentials
 
-	if appCtx.Config.ElchiInternalCommunication == "true" {
+	bridgeTLS, err := ClientTLSConfig(appCtx.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	if bridgeTLS != nil {
+		transportCredentials = credentials.NewTLS(bridgeTLS)
+	} else if appCtx.Config.ElchiInternalCommunication == "true" {
 		transportCredentials = insecure.NewCredentials()
 	} else if appCtx.Config.ElchiTLSEnabled == "true" {
 		tlsConfig := &tls.Config{
@@ -34,8 +41,7 @@ func NewGRPCClient(appCtx *db.AppContext) (*grpc.ClientConn, error) {
 		transportCredentials = insecure.NewCredentials()
 	}
 
-	return grpc.NewClient(
-		GetElchiAddressPort(appCtx),
+	dialOptions := []grpc.DialOption{
 		grpc.WithTransportCredentials(transportCredentials),
 		grpc.WithContextDialer(ipv4Dialer),
 		grpc.WithDisableServiceConfig(),
@@ -44,7 +50,7 @@ func NewGRPCClient(appCtx *db.AppContext) (*grpc.ClientConn, error) {
 			Timeout:             5 * time.Second,
 			PermitWithoutStream: true,
 		}),
-		grpc.WithAuthority(appCtx.Config.ElchiAddress),
+		grpc.WithAuthority(getAuthority(appCtx)),
 		grpc.WithDefaultCallOptions(
 			grpc.WaitForReady(true),
 		),
@@ -56,12 +62,31 @@ func NewGRPCClient(appCtx *db.AppContext) (*grpc.ClientConn, error) {
 				MaxDelay:   10 * time.Second,
 			},
 		}),
-	)
+	}
+
+	if appCtx.Config.ElchiBridgeToken != "" {
+		secure := transportCredentials.Info().SecurityProtocol != "insecure"
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(NewTokenCredentials(appCtx.Config.ElchiBridgeToken, secure)))
+	}
+
+	return grpc.NewClient(GetElchiAddressPort(appCtx), dialOptions...)
 }
 
 func GetElchiAddressPort(appCtx *db.AppContext) string {
+	if appCtx.Config.ElchiBridgeAddressPort != "" {
+		return appCtx.Config.ElchiBridgeAddressPort
+	}
 	if appCtx.Config.ElchiInternalCommunication == "true" {
 		return appCtx.Config.ElchiInternalAddressPort
 	}
 	return appCtx.Config.ElchiAddress + ":" + appCtx.Config.ElchiPort
 }
+
+func getAuthority(appCtx *db.AppContext) string {
+	if appCtx.Config.ElchiBridgeAddressPort != "" {
+		if host, _, err := net.SplitHostPort(appCtx.Config.ElchiBridgeAddressPort); err == nil {
+			return host
+		}
+	}
+	return appCtx.Config.ElchiAddress
+}
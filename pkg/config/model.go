@@ -8,6 +8,14 @@ type AppConfig struct {
 	ElchiVersions              []string `mapstructure:"ELCHI_VERSIONS" yaml:"ELCHI_VERSIONS"`
 	ElchiInternalCommunication string   `mapstructure:"ELCHI_INTERNAL_COMMUNICATION" yaml:"ELCHI_INTERNAL_COMMUNICATION"`
 	ElchiInternalAddressPort   string   `mapstructure:"ELCHI_INTERNAL_ADDRESS_PORT" yaml:"ELCHI_INTERNAL_ADDRESS_PORT"`
+	ElchiTrashRetention        string   `mapstructure:"ELCHI_TRASH_RETENTION" yaml:"ELCHI_TRASH_RETENTION"`
+
+	ElchiBridgePort        string `mapstructure:"ELCHI_BRIDGE_PORT" yaml:"ELCHI_BRIDGE_PORT"`
+	ElchiBridgeAddressPort string `mapstructure:"ELCHI_BRIDGE_ADDRESS_PORT" yaml:"ELCHI_BRIDGE_ADDRESS_PORT"`
+	ElchiBridgeToken       string `mapstructure:"ELCHI_BRIDGE_TOKEN" yaml:"ELCHI_BRIDGE_TOKEN"`
+	ElchiBridgeCAFile      string `mapstructure:"ELCHI_BRIDGE_CA_FILE" yaml:"ELCHI_BRIDGE_CA_FILE"`
+	ElchiBridgeCertFile    string `mapstructure:"ELCHI_BRIDGE_CERT_FILE" yaml:"ELCHI_BRIDGE_CERT_FILE"`
+	ElchiBridgeKeyFile     string `mapstructure:"ELCHI_BRIDGE_KEY_FILE" yaml:"ELCHI_BRIDGE_KEY_FILE"`
 
 	MongodbHosts      string `mapstructure:"MONGODB_HOSTS" yaml:"MONGODB_HOSTS"`
 	MongodbUsername   string `mapstructure:"MONGODB_USERNAME" yaml:"MONGODB_USERNAME"`
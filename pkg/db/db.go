@@ -14,14 +14,16 @@ import (
 
 	"github.com/CloudNativeWorks/elchi-backend/pkg/config"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/invalidation"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 )
 
 type AppContext struct {
-	Client *mongo.Database
-	Logger *logger.Logger
-	Config *config.AppConfig
+	Client       *mongo.Database
+	Logger       *logger.Logger
+	Config       *config.AppConfig
+	Invalidation *invalidation.Bus
 }
 
 var (
@@ -34,6 +36,7 @@ var (
 	generalName                    = "general.name"
 	generalVersion                 = "general.version"
 	generalNameProject             = "general_name_version_project_1"
+	listIndexName                  = "list_order_1"
 )
 
 var Indices = map[string]mongo.IndexModel{
@@ -56,6 +59,22 @@ var Indices = map[string]mongo.IndexModel{
 	"settings":      {Keys: bson.M{"project": 1}, Options: options.Index().SetUnique(true).SetName("project_name_1").SetCollation(&options.Collation{Locale: "en", Strength: 2})},
 }
 
+// listIndices back the default order of the list endpoints. They use the simple
+// collation list queries sort with, so they cannot share the unique indices above.
+func listIndices() map[string]mongo.IndexModel {
+	indices := map[string]mongo.IndexModel{
+		"services": {Keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName(listIndexName)},
+		"clients":  {Keys: bson.D{{Key: "client_id", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName(listIndexName)},
+	}
+	for _, collection := range models.XDSCollections() {
+		indices[collection] = mongo.IndexModel{
+			Keys:    bson.D{{Key: generalProject, Value: 1}, {Key: generalName, Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName(listIndexName),
+		}
+	}
+	return indices
+}
+
 func buildMongoDBConnectionString(config *config.AppConfig) string {
 	u := &url.URL{
 		Scheme: config.MongodbScheme,
@@ -109,6 +128,10 @@ func NewMongoDB(config *config.AppConfig, createDefaultResources bool) *AppConte
 		logger.Fatal("MongoDB connection error:", err)
 	}
 
+	if err := checkTransactions(ctx, client); err != nil {
+		logger.Fatal("MongoDB deployment error:", err)
+	}
+
 	database := client.Database(config.MongodbDatabase)
 	err = collectCreateIndex(ctx, database, logger)
 	if err != nil {
@@ -128,6 +151,22 @@ func NewMongoDB(config *config.AppConfig, createDefaultResources bool) *AppConte
 	return context
 }
 
+// checkTransactions makes sure the server runs transactions, which only replica sets
+// and sharded clusters do.
+func checkTransactions(ctx context.Context, client *mongo.Client) error {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return fmt.Errorf("could not read the deployment type: %w", err)
+	}
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		return errors.New("a standalone server does not support transactions, run MongoDB as a replica set (a single-node one is enough) and set MONGODB_REPLICASET")
+	}
+	return nil
+}
+
 func (db *AppContext) GetGenerals(ctx context.Context, collectionName string) (*mongo.Cursor, error) {
 	collection := db.Client.Collection(collectionName)
 	findOptions := options.Find()
@@ -172,6 +211,13 @@ func collectCreateIndex(ctx context.Context, database *mongo.Database, logger *l
 		}
 	}
 
+	for collectionName, index := range listIndices() {
+		if err := createIndex(ctx, database.Collection(collectionName), index, listIndexName); err != nil {
+			logger.Fatal("Failed to create list index for", collectionName, ":", err)
+			return err
+		}
+	}
+
 	return nil
 }
 
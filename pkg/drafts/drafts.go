@@ -0,0 +1,200 @@
+package drafts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const CollectionName = "drafts"
+
+var ErrDraftNotFound = errors.New("draft not found")
+
+// Draft is a pending update of a live resource. It is never read by snapshot
+// generation, publishing it writes the body to the live resource.
+type Draft struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Collection  string             `json:"collection" bson:"collection"`
+	Name        string             `json:"name" bson:"name"`
+	Project     string             `json:"project" bson:"project"`
+	Version     string             `json:"version" bson:"version"`
+	GType       models.GTypes      `json:"gtype" bson:"gtype"`
+	ResourceID  primitive.ObjectID `json:"resource_id" bson:"resource_id"`
+	BaseVersion string             `json:"base_version" bson:"base_version"`
+	UpdatedBy   string             `json:"updated_by" bson:"updated_by"`
+	CreatedAt   primitive.DateTime `json:"created_at" bson:"created_at"`
+	UpdatedAt   primitive.DateTime `json:"updated_at" bson:"updated_at"`
+	General     *models.General    `json:"general,omitempty" bson:"general,omitempty"`
+	Resource    any                `json:"resource,omitempty" bson:"resource,omitempty"`
+}
+
+type ListFilter struct {
+	Project    string
+	Collection string
+	Name       string
+	Version    string
+}
+
+var indexModels = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "project", Value: 1}, {Key: "version", Value: 1}, {Key: "collection", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().SetName("project_version_collection_name_1").SetUnique(true),
+	},
+}
+
+func EnsureIndexes(ctx context.Context, database *mongo.Database) error {
+	_, err := database.Collection(CollectionName).Indexes().CreateMany(ctx, indexModels)
+	return err
+}
+
+// documentCollection decodes nested documents as maps so bodies marshal to plain json.
+func documentCollection(database *mongo.Database) *mongo.Collection {
+	opts := options.Collection().SetBSONOptions(&options.BSONOptions{DefaultDocumentM: true})
+	return database.Collection(CollectionName, opts)
+}
+
+// Save stores the resource as the draft of the live resource with the given id,
+// replacing an earlier draft. baseVersion is the live version the draft was made from.
+func Save(ctx context.Context, database *mongo.Database, resource models.ResourceClass, resourceID primitive.ObjectID, baseVersion string, user models.UserDetails) (*Draft, error) {
+	general := resource.GetGeneral()
+	if general.Collection == "" {
+		general.Collection = general.GType.CollectionString()
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	filter := bson.M{
+		"project":    general.Project,
+		"version":    general.Version,
+		"collection": general.Collection,
+		"name":       general.Name,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"gtype":        general.GType,
+			"resource_id":  resourceID,
+			"base_version": baseVersion,
+			"updated_by":   user.UserName,
+			"updated_at":   now,
+			"general":      general,
+			"resource":     resource.GetResource(),
+		},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After).SetProjection(bson.M{"general": 0, "resource": 0})
+	var draft Draft
+	if err := documentCollection(database).FindOneAndUpdate(ctx, filter, update, opts).Decode(&draft); err != nil {
+		return nil, fmt.Errorf("could not save draft of %s/%s: %w", general.Collection, general.Name, err)
+	}
+	return &draft, nil
+}
+
+// List returns the pending drafts, oldest first, without their bodies.
+func List(ctx context.Context, database *mongo.Database, listFilter ListFilter) ([]Draft, error) {
+	if listFilter.Project == "" {
+		return nil, errors.New("project is required")
+	}
+
+	filter := bson.M{"project": listFilter.Project}
+	if listFilter.Collection != "" {
+		filter["collection"] = listFilter.Collection
+	}
+	if listFilter.Name != "" {
+		filter["name"] = listFilter.Name
+	}
+	if listFilter.Version != "" {
+		filter["version"] = listFilter.Version
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: 1}}).
+		SetProjection(bson.M{"general": 0, "resource": 0})
+
+	cursor, err := database.Collection(CollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not list drafts: %w", err)
+	}
+
+	drafts := []Draft{}
+	if err := cursor.All(ctx, &drafts); err != nil {
+		return nil, fmt.Errorf("could not decode drafts: %w", err)
+	}
+	return drafts, nil
+}
+
+func Get(ctx context.Context, database *mongo.Database, id, project string) (*Draft, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid draft id")
+	}
+
+	var draft Draft
+	err = documentCollection(database).FindOne(ctx, bson.M{"_id": objectID, "project": project}).Decode(&draft)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrDraftNotFound
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func Delete(ctx context.Context, database *mongo.Database, id primitive.ObjectID) error {
+	if _, err := database.Collection(CollectionName).DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("could not delete draft: %w", err)
+	}
+	return nil
+}
+
+// RemoveFor drops the draft of a resource, it is called when the live resource is deleted.
+func RemoveFor(ctx context.Context, database *mongo.Database, collection, name, project, version string) error {
+	filter := bson.M{"collection": collection, "name": name, "project": project}
+	if version != "" {
+		filter["version"] = version
+	}
+
+	if _, err := database.Collection(CollectionName).DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("could not remove drafts of %s/%s: %w", collection, name, err)
+	}
+	return nil
+}
+
+func RemoveProject(ctx context.Context, database *mongo.Database, project string) error {
+	if _, err := database.Collection(CollectionName).DeleteMany(ctx, bson.M{"project": project}); err != nil {
+		return fmt.Errorf("could not remove drafts of project %s: %w", project, err)
+	}
+	return nil
+}
+
+// DBResource rebuilds the resource to write to the live collection. The resource
+// version is the base version, so publishing fails if live moved on since. The
+// body is round tripped through json so it holds the same types as a request body.
+func (d *Draft) DBResource() (*models.DBResource, error) {
+	if d.General == nil {
+		return nil, errors.New("draft has no resource body")
+	}
+
+	data, err := json.Marshal(models.DBResource{
+		ID:       d.ResourceID,
+		General:  *d.General,
+		Resource: models.Resource{Version: d.BaseVersion, Resource: d.Resource},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resource models.DBResource
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
@@ -1,6 +1,9 @@
 package errstr
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrNotAuthorized         = errors.New("you are not authorized to perform this action")
@@ -20,4 +23,26 @@ var (
 	ErrInvalidRefreshToken   = errors.New("invalid refresh token")
 	ErrUnexpectedTypeBsonM   = errors.New("unexpected type for update['$set'], expected bson.M")
 	ErrUserUpdatePermError   = errors.New("user does not have permission to update of user")
+	ErrDanglingReferences    = errors.New("unresolved references")
+	ErrVersionRequired       = errors.New("expected version is required, send it in the If-Match header or resource.version")
+	ErrVersionConflict       = errors.New("resource was modified by someone else")
+	ErrLintFailed            = errors.New("lint rules failed")
+	ErrListenerConflict      = errors.New("listener address is already in use")
 )
+
+// ConflictError is returned when an update was based on a stale version.
+type ConflictError struct {
+	CurrentVersion string
+	UpdatedBy      string
+}
+
+func (e *ConflictError) Error() string {
+	if e.UpdatedBy == "" {
+		return fmt.Sprintf("%s, current version is %s", ErrVersionConflict, e.CurrentVersion)
+	}
+	return fmt.Sprintf("%s, current version is %s (last edited by %s)", ErrVersionConflict, e.CurrentVersion, e.UpdatedBy)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
@@ -0,0 +1,182 @@
+package invalidation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/logger"
+)
+
+const (
+	collectionName = "cache_invalidations"
+	collectionSize = 1 << 20
+	// maxEvents bounds the events the collection holds, an event takes more than 64 bytes
+	maxEvents      = collectionSize / 64
+	publishTimeout = 5 * time.Second
+	retryInterval  = time.Second
+)
+
+// Event marks cached data of a resource as stale. Empty fields match everything,
+// an event with only the project set drops every entry of that project.
+type Event struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Origin     string             `bson:"origin"`
+	Collection string             `bson:"collection,omitempty"`
+	Name       string             `bson:"name,omitempty"`
+	Project    string             `bson:"project,omitempty"`
+	Version    string             `bson:"version,omitempty"`
+	CreatedAt  primitive.DateTime `bson:"created_at"`
+}
+
+type Handler func(event Event)
+
+// Bus delivers invalidations to the local subscribers right away and broadcasts
+// them to the other controller replicas through a capped collection.
+type Bus struct {
+	db       *mongo.Database
+	origin   string
+	handlers []Handler
+	mu       sync.RWMutex
+	logger   *logger.Logger
+}
+
+func NewBus(database *mongo.Database) *Bus {
+	hostname, _ := os.Hostname()
+	return &Bus{
+		db:     database,
+		origin: hostname + "-" + primitive.NewObjectID().Hex(),
+		logger: logger.NewLogger("pkg/invalidation"),
+	}
+}
+
+func (b *Bus) Subscribe(handler Handler) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish is safe on a nil bus, processes without a bus simply skip invalidation.
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+
+	event.Origin = b.origin
+	event.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+	b.dispatch(event)
+
+	if b.db == nil {
+		return
+	}
+
+	// the request context may already be cancelled once the response is written
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if _, err := b.db.Collection(collectionName).InsertOne(ctx, event); err != nil {
+		b.logger.Errorf("could not broadcast invalidation for %s/%s: %v", event.Collection, event.Name, err)
+	}
+}
+
+func (b *Bus) dispatch(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+}
+
+// Start tails the capped collection and applies invalidations published by other replicas.
+func (b *Bus) Start(ctx context.Context) {
+	if b == nil || b.db == nil {
+		return
+	}
+
+	if err := b.ensureCollection(ctx); err != nil {
+		b.logger.Errorf("could not create %s collection: %v", collectionName, err)
+		return
+	}
+
+	go b.tail(ctx)
+}
+
+func (b *Bus) ensureCollection(ctx context.Context) error {
+	opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(collectionSize)
+	err := b.db.CreateCollection(ctx, collectionName, opts)
+
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Name == "NamespaceExists" {
+		return nil
+	}
+	return err
+}
+
+// tail reads the capped collection in natural order, which is the insertion order.
+// Ids are generated by the publishing replicas and do not follow that order, so a
+// reopened cursor reads the collection from the start and skips the events already
+// seen. Events published before the start only drop entries of the empty caches.
+func (b *Bus) tail(ctx context.Context) {
+	seen := newSeenEvents(maxEvents)
+	opts := options.Find().SetCursorType(options.TailableAwait).SetMaxAwaitTime(retryInterval)
+
+	for ctx.Err() == nil {
+		cursor, err := b.db.Collection(collectionName).Find(ctx, bson.M{}, opts)
+		if err != nil {
+			b.logger.Debugf("could not tail %s: %v", collectionName, err)
+			time.Sleep(retryInterval)
+			continue
+		}
+
+		for cursor.Next(ctx) {
+			var event Event
+			if err := cursor.Decode(&event); err != nil {
+				b.logger.Errorf("could not decode invalidation: %v", err)
+				continue
+			}
+			if seen.add(event.ID) && event.Origin != b.origin {
+				b.dispatch(event)
+			}
+		}
+
+		// a tailable cursor dies when the collection is empty or the cursor falls behind
+		_ = cursor.Close(context.Background())
+		time.Sleep(retryInterval)
+	}
+}
+
+// seenEvents remembers the ids of the latest events read, a ring as large as the
+// number of events the capped collection can hold.
+type seenEvents struct {
+	ids  []primitive.ObjectID
+	next int
+	set  map[primitive.ObjectID]bool
+}
+
+func newSeenEvents(size int) *seenEvents {
+	return &seenEvents{ids: make([]primitive.ObjectID, size), set: make(map[primitive.ObjectID]bool, size)}
+}
+
+// add reports whether the event was not seen before.
+func (s *seenEvents) add(id primitive.ObjectID) bool {
+	if s.set[id] {
+		return false
+	}
+	delete(s.set, s.ids[s.next])
+	s.ids[s.next] = id
+	s.set[id] = true
+	s.next = (s.next + 1) % len(s.ids)
+	return true
+}
@@ -0,0 +1,227 @@
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Field is the document field the labels of a resource are stored in.
+const Field = "general.labels"
+
+const (
+	OpEquals    = "="
+	OpNotEquals = "!="
+	OpIn        = "in"
+	OpNotIn     = "notin"
+	OpExists    = "exists"
+	OpNotExists = "!"
+)
+
+var (
+	keyName   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.-]{0,61}[A-Za-z0-9])?$`)
+	keyPrefix = regexp.MustCompile(`^[a-z0-9]([a-z0-9.-]{0,251}[a-z0-9])?$`)
+	value     = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9_.-]{0,61}[A-Za-z0-9])?)?$`)
+)
+
+// Requirement is one term of a selector, such as team=payments or env in (prod,stage).
+type Requirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// Selector matches the labels holding every requirement, an empty selector matches
+// everything.
+type Selector []Requirement
+
+// Parse reads a comma separated selector in the Kubernetes syntax: k=v, k==v, k!=v,
+// k in (a,b), k notin (a,b), k for an existing key and !k for a missing one.
+func Parse(selector string) (Selector, error) {
+	var result Selector
+	for _, term := range splitTerms(selector) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		requirement, err := parseTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, requirement)
+	}
+	return result, nil
+}
+
+func parseTerm(term string) (Requirement, error) {
+	if key, ok := strings.CutPrefix(term, "!"); ok {
+		return newRequirement(strings.TrimSpace(key), OpNotExists, nil)
+	}
+
+	for _, op := range []string{"!=", "==", "="} {
+		if key, val, ok := strings.Cut(term, op); ok {
+			operator := OpEquals
+			if op == "!=" {
+				operator = OpNotEquals
+			}
+			return newRequirement(strings.TrimSpace(key), operator, []string{strings.TrimSpace(val)})
+		}
+	}
+
+	if fields := strings.Fields(term); len(fields) >= 2 && (fields[1] == OpIn || fields[1] == OpNotIn) {
+		list := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(term, fields[0])), fields[1]))
+		if !strings.HasPrefix(list, "(") || !strings.HasSuffix(list, ")") {
+			return Requirement{}, fmt.Errorf("invalid selector %q: %s needs a list in parentheses", term, fields[1])
+		}
+		var values []string
+		for _, v := range strings.Split(list[1:len(list)-1], ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+		return newRequirement(fields[0], fields[1], values)
+	}
+
+	return newRequirement(term, OpExists, nil)
+}
+
+func newRequirement(key, operator string, values []string) (Requirement, error) {
+	if err := ValidateKey(key); err != nil {
+		return Requirement{}, fmt.Errorf("invalid selector: %w", err)
+	}
+	for _, v := range values {
+		if err := ValidateValue(v); err != nil {
+			return Requirement{}, fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+	if (operator == OpIn || operator == OpNotIn) && len(values) == 0 {
+		return Requirement{}, fmt.Errorf("invalid selector: %s %s needs at least one value", key, operator)
+	}
+	return Requirement{Key: key, Operator: operator, Values: values}, nil
+}
+
+// splitTerms splits at the commas outside of value lists.
+func splitTerms(selector string) []string {
+	var terms []string
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(terms, selector[start:])
+}
+
+// Filter returns the mongo filter of the selector on the labels stored in field.
+// Dots are not allowed in the keys of mongo paths, such keys are matched through
+// $getField.
+func (s Selector) Filter(field string) bson.M {
+	if len(s) == 0 {
+		return bson.M{}
+	}
+
+	clauses := make(bson.A, 0, len(s))
+	for _, r := range s {
+		clauses = append(clauses, r.filter(field))
+	}
+	if len(clauses) == 1 {
+		return clauses[0].(bson.M)
+	}
+	return bson.M{"$and": clauses}
+}
+
+func (r Requirement) filter(field string) bson.M {
+	if strings.ContainsAny(r.Key, ".$") {
+		return bson.M{"$expr": r.expr(field)}
+	}
+
+	path := field + "." + r.Key
+	switch r.Operator {
+	case OpEquals:
+		return bson.M{path: r.Values[0]}
+	case OpNotEquals:
+		return bson.M{path: bson.M{"$ne": r.Values[0]}}
+	case OpIn:
+		return bson.M{path: bson.M{"$in": r.Values}}
+	case OpNotIn:
+		return bson.M{path: bson.M{"$nin": r.Values}}
+	case OpNotExists:
+		return bson.M{path: bson.M{"$exists": false}}
+	default:
+		return bson.M{path: bson.M{"$exists": true}}
+	}
+}
+
+func (r Requirement) expr(field string) bson.M {
+	labelValue := bson.M{"$getField": bson.M{
+		"field": bson.M{"$literal": r.Key},
+		"input": bson.M{"$ifNull": bson.A{"$" + field, bson.M{}}},
+	}}
+	missing := bson.M{"$eq": bson.A{bson.M{"$type": labelValue}, "missing"}}
+
+	switch r.Operator {
+	case OpEquals:
+		return bson.M{"$eq": bson.A{labelValue, r.Values[0]}}
+	case OpNotEquals:
+		return bson.M{"$ne": bson.A{labelValue, r.Values[0]}}
+	case OpIn:
+		return bson.M{"$in": bson.A{labelValue, r.Values}}
+	case OpNotIn:
+		return bson.M{"$not": bson.A{bson.M{"$in": bson.A{labelValue, r.Values}}}}
+	case OpNotExists:
+		return missing
+	default:
+		return bson.M{"$not": bson.A{missing}}
+	}
+}
+
+// ValidateKey checks a label key: an optional DNS subdomain prefix and a slash, then
+// a name of up to 63 alphanumerics, dashes, underscores and dots.
+func ValidateKey(key string) error {
+	name := key
+	if prefix, rest, ok := strings.Cut(key, "/"); ok {
+		if !keyPrefix.MatchString(prefix) {
+			return fmt.Errorf("label key %q has an invalid prefix", key)
+		}
+		name = rest
+	}
+	if !keyName.MatchString(name) {
+		return fmt.Errorf("label key %q is invalid, use up to 63 alphanumerics, '-', '_' or '.' starting and ending with an alphanumeric", key)
+	}
+	return nil
+}
+
+// ValidateValue checks a label value, it may be empty.
+func ValidateValue(v string) error {
+	if !value.MatchString(v) {
+		return fmt.Errorf("label value %q is invalid, use up to 63 alphanumerics, '-', '_' or '.' starting and ending with an alphanumeric", v)
+	}
+	return nil
+}
+
+// Validate checks every key and value of the labels.
+func Validate(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := ValidateKey(key); err != nil {
+			return err
+		}
+		if err := ValidateValue(labels[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+)
+
+// Finding is a problem a rule found at Path, a gjson style path into the resource.
+type Finding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+}
+
+// Lookup gives rules read access to the other resources of the same project and version.
+type Lookup interface {
+	// Resource returns the decoded resource of collection/name, nil when it does not exist.
+	Resource(ctx context.Context, collection, name string) (any, error)
+	// Referrers returns the resources pointing to collection/name.
+	Referrers(ctx context.Context, collection, name string) ([]references.Endpoint, error)
+}
+
+// Target is the resource being linted. Resource is the decoded JSON of the resource,
+// a list for array resources. Lookup is nil when other resources cannot be read.
+type Target struct {
+	General  models.General
+	Resource any
+	Lookup   Lookup
+}
+
+// Rule checks resources of its gtypes. Check reports findings without a severity, the
+// engine fills in the default severity or the one configured for the project.
+type Rule interface {
+	ID() string
+	Description() string
+	DefaultSeverity() string
+	GTypes() []models.GTypes
+	Check(ctx context.Context, target *Target) ([]Finding, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Rule{}
+)
+
+// Register adds a rule to the engine, rule ids have to be unique.
+func Register(rule Rule) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[rule.ID()]; exists {
+		panic("lint: rule registered twice: " + rule.ID())
+	}
+	registry[rule.ID()] = rule
+}
+
+// Rules returns the registered rules sorted by id.
+func Rules() []Rule {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	rules := make([]Rule, 0, len(registry))
+	for _, rule := range registry {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID() < rules[j].ID() })
+	return rules
+}
+
+// CheckSeverities rejects project severities of rules that are not registered.
+func CheckSeverities(severities map[string]string) error {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for id := range severities {
+		if _, exists := registry[id]; !exists {
+			return fmt.Errorf("unknown lint rule: %s", id)
+		}
+	}
+	return nil
+}
+
+// Severity returns the severity of the rule with the project severities applied.
+func Severity(rule Rule, severities map[string]string) string {
+	if severity, ok := severities[rule.ID()]; ok && severity != "" {
+		return severity
+	}
+	return rule.DefaultSeverity()
+}
+
+// Run checks the target with every rule of its gtype. Rules switched off in severities
+// and findings suppressed in the metadata of the resource are left out.
+func Run(ctx context.Context, target *Target, severities map[string]string) ([]Finding, error) {
+	suppressions := parseSuppressions(target.General.Metadata)
+	findings := []Finding{}
+	for _, rule := range Rules() {
+		severity := Severity(rule, severities)
+		if severity == models.LintOff || !appliesTo(rule, target.General.GType) {
+			continue
+		}
+
+		found, err := rule.Check(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("lint rule %s: %w", rule.ID(), err)
+		}
+		for _, finding := range found {
+			finding.Rule = rule.ID()
+			finding.Severity = severity
+			if suppressions.suppressed(finding) {
+				continue
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+	return findings, nil
+}
+
+// Errors returns the findings with error severity.
+func Errors(findings []Finding) []Finding {
+	var errors []Finding
+	for _, finding := range findings {
+		if finding.Severity == models.LintError {
+			errors = append(errors, finding)
+		}
+	}
+	return errors
+}
+
+func appliesTo(rule Rule, gtype models.GTypes) bool {
+	for _, ruleGType := range rule.GTypes() {
+		if ruleGType == gtype {
+			return true
+		}
+	}
+	return false
+}
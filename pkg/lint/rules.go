@@ -0,0 +1,392 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+)
+
+// rule is a built-in rule, check implements Rule.Check.
+type rule struct {
+	id          string
+	description string
+	severity    string
+	gtypes      []models.GTypes
+	check       func(ctx context.Context, target *Target) ([]Finding, error)
+}
+
+func (r *rule) ID() string              { return r.id }
+func (r *rule) Description() string     { return r.description }
+func (r *rule) DefaultSeverity() string { return r.severity }
+func (r *rule) GTypes() []models.GTypes { return r.gtypes }
+func (r *rule) Check(ctx context.Context, target *Target) ([]Finding, error) {
+	return r.check(ctx, target)
+}
+
+var routeGTypes = []models.GTypes{models.Route, models.VirtualHost, models.HTTPConnectionManager}
+
+func init() {
+	Register(&rule{
+		id:          "eds-empty-endpoints",
+		description: "An EDS cluster points to a cluster load assignment without endpoints",
+		severity:    models.LintWarning,
+		gtypes:      []models.GTypes{models.Cluster, models.Endpoint},
+		check:       checkEmptyEndpoints,
+	})
+	Register(&rule{
+		id:          "route-no-catch-all",
+		description: "A virtual host has no route matching every path",
+		severity:    models.LintWarning,
+		gtypes:      routeGTypes,
+		check:       checkCatchAll,
+	})
+	Register(&rule{
+		id:          "hcm-router-last",
+		description: "The last http filter of a http connection manager is not the router",
+		severity:    models.LintError,
+		gtypes:      []models.GTypes{models.HTTPConnectionManager},
+		check:       checkRouterLast,
+	})
+	Register(&rule{
+		id:          "duplicate-domains",
+		description: "A domain is used by more than one virtual host of a route configuration",
+		severity:    models.LintError,
+		gtypes:      routeGTypes,
+		check:       checkDuplicateDomains,
+	})
+	Register(&rule{
+		id:          "unknown-per-filter-config",
+		description: "A typed_per_filter_config entry names a filter which is not in the http filters",
+		severity:    models.LintWarning,
+		gtypes:      routeGTypes,
+		check:       checkPerFilterConfig,
+	})
+	Register(&rule{
+		id:          "weighted-clusters-total",
+		description: "The weights of weighted clusters do not sum to the total weight",
+		severity:    models.LintError,
+		gtypes:      routeGTypes,
+		check:       checkWeightedClusters,
+	})
+}
+
+func checkEmptyEndpoints(ctx context.Context, target *Target) ([]Finding, error) {
+	doc := asMap(target.Resource)
+	if doc == nil || target.Lookup == nil {
+		return nil, nil
+	}
+
+	if target.General.GType == models.Endpoint {
+		if hasEndpoints(doc) {
+			return nil, nil
+		}
+		referrers, err := target.Lookup.Referrers(ctx, models.Endpoint.CollectionString(), target.General.Name)
+		if err != nil {
+			return nil, err
+		}
+		clusters := names(referrers, models.Cluster)
+		if len(clusters) == 0 {
+			return nil, nil
+		}
+		return []Finding{{
+			Path:    "endpoints",
+			Message: fmt.Sprintf("there are no endpoints, the EDS clusters %s have no hosts", strings.Join(clusters, ", ")),
+		}}, nil
+	}
+
+	if asString(doc["type"]) != "EDS" {
+		return nil, nil
+	}
+	path := "eds_cluster_config.service_name"
+	serviceName := asString(asMap(doc["eds_cluster_config"])["service_name"])
+	if serviceName == "" {
+		path, serviceName = "name", asString(doc["name"])
+	}
+	if serviceName == "" {
+		return nil, nil
+	}
+
+	assignment, err := target.Lookup.Resource(ctx, models.Endpoint.CollectionString(), serviceName)
+	if err != nil || assignment == nil {
+		return nil, err
+	}
+	if hasEndpoints(asMap(assignment)) {
+		return nil, nil
+	}
+	return []Finding{{
+		Path:    path,
+		Message: fmt.Sprintf("cluster load assignment %s has no endpoints, the cluster has no hosts", serviceName),
+	}}, nil
+}
+
+func hasEndpoints(assignment map[string]any) bool {
+	for _, item := range asList(assignment["endpoints"]) {
+		locality := asMap(item)
+		if len(asList(locality["lb_endpoints"])) > 0 || locality["leds_cluster_locality_config"] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func checkCatchAll(_ context.Context, target *Target) ([]Finding, error) {
+	var findings []Finding
+	for _, virtualHost := range virtualHosts(target) {
+		routes := children(virtualHost, "routes")
+		catchAll := false
+		for _, route := range routes {
+			if isCatchAll(asMap(route.value["match"])) {
+				catchAll = true
+				break
+			}
+		}
+		if catchAll {
+			continue
+		}
+		findings = append(findings, Finding{
+			Path:    virtualHost.path,
+			Message: fmt.Sprintf("virtual host %s has no catch-all route, requests matching no route are answered with 404", asString(virtualHost.value["name"])),
+		})
+	}
+	return findings, nil
+}
+
+var catchAllRegexes = []string{".*", "/.*", "^.*$", "^/.*", "^/.*$"}
+
+// isCatchAll reports whether the route match takes every request, a match with
+// conditions on headers, query parameters or anything else besides the path does not.
+func isCatchAll(match map[string]any) bool {
+	matchesAll := false
+	for key, value := range match {
+		switch key {
+		case "prefix":
+			prefix := asString(value)
+			matchesAll = prefix == "" || prefix == "/"
+		case "safe_regex":
+			matchesAll = helper.Contains(catchAllRegexes, asString(asMap(value)["regex"]))
+		case "case_sensitive":
+		default:
+			return false
+		}
+	}
+	return matchesAll
+}
+
+func checkRouterLast(_ context.Context, target *Target) ([]Finding, error) {
+	filters := asList(asMap(target.Resource)["http_filters"])
+	if len(filters) == 0 {
+		return []Finding{{Path: "http_filters", Message: "there are no http filters, the router has to be the last http filter"}}, nil
+	}
+
+	last := len(filters) - 1
+	if isRouter(asMap(filters[last])) {
+		return nil, nil
+	}
+
+	message := "there is no router, the router has to be the last http filter"
+	for i, filter := range filters {
+		if isRouter(asMap(filter)) {
+			message = fmt.Sprintf("the router is http_filters.%d, it has to be the last http filter", i)
+			break
+		}
+	}
+	return []Finding{{Path: join("http_filters", last), Message: message}}, nil
+}
+
+// isRouter reports whether the http filter is the router, typed configs are stored with
+// type_url and value by Elchi and with @type by Envoy.
+func isRouter(filter map[string]any) bool {
+	typedConfig := asMap(filter["typed_config"])
+	for _, key := range []string{"type_url", "@type"} {
+		if strings.HasSuffix(asString(typedConfig[key]), models.Router.String()) {
+			return true
+		}
+	}
+	for _, typeURL := range asList(asMap(filter["config_discovery"])["type_urls"]) {
+		if strings.HasSuffix(asString(typeURL), models.Router.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkDuplicateDomains(_ context.Context, target *Target) ([]Finding, error) {
+	var findings []Finding
+	for _, group := range virtualHostGroups(target) {
+		seen := map[string]string{}
+		for _, virtualHost := range group {
+			for i, item := range asList(virtualHost.value["domains"]) {
+				domain := strings.ToLower(asString(item))
+				path := join(virtualHost.path, "domains", i)
+				if first, exists := seen[domain]; exists {
+					findings = append(findings, Finding{
+						Path:    path,
+						Message: fmt.Sprintf("domain %s is already used at %s", asString(item), first),
+					})
+					continue
+				}
+				seen[domain] = path
+			}
+		}
+	}
+	return findings, nil
+}
+
+func checkPerFilterConfig(ctx context.Context, target *Target) ([]Finding, error) {
+	configs := routeConfigs(target)
+	for _, virtualHost := range virtualHosts(target) {
+		configs = append(configs, virtualHost)
+		for _, route := range children(virtualHost, "routes") {
+			configs = append(configs, route)
+			weighted := node{path: join(route.path, "route", "weighted_clusters"), value: asMap(asMap(route.value["route"])["weighted_clusters"])}
+			configs = append(configs, children(weighted, "clusters")...)
+		}
+	}
+
+	var perFilter []node
+	for _, config := range configs {
+		if object := asMap(config.value["typed_per_filter_config"]); len(object) > 0 {
+			perFilter = append(perFilter, node{path: join(config.path, "typed_per_filter_config"), value: object})
+		}
+	}
+	if len(perFilter) == 0 {
+		return nil, nil
+	}
+
+	chains, err := httpFilterChains(ctx, target)
+	if err != nil || len(chains) == 0 {
+		return nil, err
+	}
+	managers := make([]string, 0, len(chains))
+	for manager := range chains {
+		managers = append(managers, manager)
+	}
+	sort.Strings(managers)
+
+	var findings []Finding
+	for _, config := range perFilter {
+		filters := make([]string, 0, len(config.value))
+		for filter := range config.value {
+			filters = append(filters, filter)
+		}
+		sort.Strings(filters)
+
+		for _, filter := range filters {
+			var missing []string
+			for _, manager := range managers {
+				if !chains[manager][filter] {
+					missing = append(missing, manager)
+				}
+			}
+			if len(missing) == 0 {
+				continue
+			}
+			findings = append(findings, Finding{
+				Path:    join(config.path, helper.EscapePointKey(filter)),
+				Message: fmt.Sprintf("http filter %s is not in the http filters of %s", filter, strings.Join(missing, ", ")),
+			})
+		}
+	}
+	return findings, nil
+}
+
+// httpFilterChains returns the http filter names of the http connection managers using
+// the target, by their names.
+func httpFilterChains(ctx context.Context, target *Target) (map[string]map[string]bool, error) {
+	if target.General.GType == models.HTTPConnectionManager {
+		return map[string]map[string]bool{target.General.Name: filterNames(asMap(target.Resource))}, nil
+	}
+	if target.Lookup == nil {
+		return nil, nil
+	}
+
+	configNames := []string{target.General.Name}
+	if target.General.GType == models.VirtualHost {
+		referrers, err := target.Lookup.Referrers(ctx, models.VirtualHost.CollectionString(), target.General.Name)
+		if err != nil {
+			return nil, err
+		}
+		configNames = names(referrers, models.Route)
+	}
+
+	chains := map[string]map[string]bool{}
+	for _, routeConfig := range configNames {
+		referrers, err := target.Lookup.Referrers(ctx, models.Route.CollectionString(), routeConfig)
+		if err != nil {
+			return nil, err
+		}
+		for _, manager := range names(referrers, models.HTTPConnectionManager) {
+			if _, loaded := chains[manager]; loaded {
+				continue
+			}
+			resource, err := target.Lookup.Resource(ctx, models.HTTPConnectionManager.CollectionString(), manager)
+			if err != nil {
+				return nil, err
+			}
+			if resource != nil {
+				chains[manager] = filterNames(asMap(resource))
+			}
+		}
+	}
+	return chains, nil
+}
+
+func filterNames(manager map[string]any) map[string]bool {
+	filters := map[string]bool{}
+	for _, filter := range asList(manager["http_filters"]) {
+		if name := asString(asMap(filter)["name"]); name != "" {
+			filters[name] = true
+		}
+	}
+	return filters
+}
+
+func names(endpoints []references.Endpoint, gtype models.GTypes) []string {
+	var result []string
+	for _, endpoint := range endpoints {
+		if endpoint.GType == gtype && !helper.Contains(result, endpoint.Name) {
+			result = append(result, endpoint.Name)
+		}
+	}
+	return result
+}
+
+func checkWeightedClusters(_ context.Context, target *Target) ([]Finding, error) {
+	var findings []Finding
+	for _, virtualHost := range virtualHosts(target) {
+		for _, route := range children(virtualHost, "routes") {
+			weighted := asMap(asMap(route.value["route"])["weighted_clusters"])
+			if weighted == nil {
+				continue
+			}
+
+			sum := 0.0
+			for _, cluster := range asList(weighted["clusters"]) {
+				if weight, ok := asNumber(asMap(cluster)["weight"]); ok {
+					sum += weight
+				}
+			}
+
+			path := join(route.path, "route", "weighted_clusters")
+			total, hasTotal := asNumber(weighted["total_weight"])
+			switch {
+			case hasTotal && total > 0 && sum != total:
+				findings = append(findings, Finding{
+					Path:    path,
+					Message: fmt.Sprintf("the weights of the clusters sum to %v instead of total_weight %v", sum, total),
+				})
+			case sum == 0:
+				findings = append(findings, Finding{
+					Path:    path,
+					Message: "the weights of the clusters sum to 0, no cluster gets traffic",
+				})
+			}
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,66 @@
+package lint
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SuppressKey is the key of General.Metadata listing the suppressed rules of a resource.
+// An entry is a rule id, or rule@path to suppress the rule at path and below only. The
+// value is a list of entries or a comma separated string.
+const SuppressKey = "lint_suppress"
+
+type suppression struct {
+	rule string
+	path string
+}
+
+type suppressions []suppression
+
+func parseSuppressions(metadata map[string]any) suppressions {
+	var entries []string
+	switch value := metadata[SuppressKey].(type) {
+	case string:
+		entries = strings.Split(value, ",")
+	case []string:
+		entries = value
+	case []any:
+		entries = stringEntries(value)
+	case primitive.A:
+		entries = stringEntries(value)
+	}
+
+	result := make(suppressions, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		rule, path, _ := strings.Cut(entry, "@")
+		result = append(result, suppression{rule: strings.TrimSpace(rule), path: strings.TrimSpace(path)})
+	}
+	return result
+}
+
+func stringEntries(values []any) []string {
+	entries := make([]string, 0, len(values))
+	for _, value := range values {
+		if entry, ok := value.(string); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func (s suppressions) suppressed(finding Finding) bool {
+	for _, entry := range s {
+		if entry.rule != finding.Rule {
+			continue
+		}
+		if entry.path == "" || entry.path == finding.Path || strings.HasPrefix(finding.Path, entry.path+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package lint
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+// node is an object of the resource and its path.
+type node struct {
+	path  string
+	value map[string]any
+}
+
+func join(prefix string, parts ...any) string {
+	segments := make([]string, 0, len(parts)+1)
+	if prefix != "" {
+		segments = append(segments, prefix)
+	}
+	for _, part := range parts {
+		switch part := part.(type) {
+		case int:
+			segments = append(segments, strconv.Itoa(part))
+		case string:
+			segments = append(segments, part)
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
+func asMap(value any) map[string]any {
+	object, _ := value.(map[string]any)
+	return object
+}
+
+func asList(value any) []any {
+	list, _ := value.([]any)
+	return list
+}
+
+func asString(value any) string {
+	text, _ := value.(string)
+	return text
+}
+
+// asNumber reads a JSON number, 64 bit integers are rendered as strings.
+func asNumber(value any) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case json.Number:
+		parsed, err := number.Float64()
+		return parsed, err == nil
+	case string:
+		parsed, err := strconv.ParseFloat(number, 64)
+		return parsed, err == nil
+	}
+	return 0, false
+}
+
+// children returns the objects of the list at key of parent.
+func children(parent node, key string) []node {
+	var nodes []node
+	for i, item := range asList(parent.value[key]) {
+		if object := asMap(item); object != nil {
+			nodes = append(nodes, node{path: join(parent.path, key, i), value: object})
+		}
+	}
+	return nodes
+}
+
+// routeConfigs returns the route configurations of the target, a route configuration
+// itself or the one inlined into a http connection manager.
+func routeConfigs(target *Target) []node {
+	doc := asMap(target.Resource)
+	switch target.General.GType {
+	case models.Route:
+		if doc != nil {
+			return []node{{value: doc}}
+		}
+	case models.HTTPConnectionManager:
+		if routeConfig := asMap(doc["route_config"]); routeConfig != nil {
+			return []node{{path: "route_config", value: routeConfig}}
+		}
+	}
+	return nil
+}
+
+// virtualHostGroups returns the virtual hosts of the target grouped by the route
+// configuration they belong to. Virtual host resources are a list served through vhds.
+func virtualHostGroups(target *Target) [][]node {
+	if target.General.GType == models.VirtualHost {
+		if object := asMap(target.Resource); object != nil {
+			return [][]node{{{value: object}}}
+		}
+		var group []node
+		for i, item := range asList(target.Resource) {
+			if object := asMap(item); object != nil {
+				group = append(group, node{path: strconv.Itoa(i), value: object})
+			}
+		}
+		return [][]node{group}
+	}
+
+	var groups [][]node
+	for _, routeConfig := range routeConfigs(target) {
+		groups = append(groups, children(routeConfig, "virtual_hosts"))
+	}
+	return groups
+}
+
+func virtualHosts(target *Target) []node {
+	var nodes []node
+	for _, group := range virtualHostGroups(target) {
+		nodes = append(nodes, group...)
+	}
+	return nodes
+}
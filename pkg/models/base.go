@@ -1,5 +1,14 @@
 package models
 
+// Values of RequestDetails.SaveOrPublish. SaveDraft keeps an update next to the
+// live resource until it is published. SaveLive writes the live resource without
+// a poke and is only set internally by callers which publish on their own.
+const (
+	SaveDraft   = "save"
+	SavePublish = "publish"
+	SaveLive    = "live"
+)
+
 type RequestDetails struct {
 	ResourceID     string
 	Collection     string
@@ -18,14 +27,33 @@ type RequestDetails struct {
 	ServiceID      string
 	FromClient     string
 	ForMetrics     string
+	IfMatch        string
+	List           ListOptions
+	Selector       string
+	Permanent      string
+}
+
+// ListOptions are the limit, cursor, sort and fields parameters of list endpoints.
+// A list is paginated once a limit or a cursor is given, sort is a field name with
+// an optional - for descending order.
+type ListOptions struct {
+	Limit  int
+	Cursor string
+	Sort   string
+	Fields []string
+}
+
+func (l ListOptions) Paged() bool {
+	return l.Limit > 0 || l.Cursor != ""
 }
 
 type UserDetails struct {
-	Groups    []string
-	Projects  []string
-	BaseGroup string
-	Role      Role
-	IsOwner   bool
-	UserID    string
-	UserName  string
+	Groups      []string
+	Projects    []string
+	BaseGroup   string
+	Role        Role
+	IsOwner     bool
+	UserID      string
+	UserName    string
+	LabelScopes []LabelScope
 }
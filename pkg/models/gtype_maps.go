@@ -1,6 +1,8 @@
 package models
 
 import (
+	"sort"
+
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
@@ -630,6 +632,31 @@ var gTypeMappings = map[GTypes]GTypeMapping{
 	},
 }
 
+// XDSCollections returns every collection that holds xds resources, sorted by name.
+func XDSCollections() []string {
+	seen := make(map[string]struct{})
+	collections := []string{}
+	for _, mapping := range gTypeMappings {
+		if _, ok := seen[mapping.Collection]; ok {
+			continue
+		}
+		seen[mapping.Collection] = struct{}{}
+		collections = append(collections, mapping.Collection)
+	}
+	sort.Strings(collections)
+	return collections
+}
+
+// AllGTypes returns every known gtype, sorted.
+func AllGTypes() []GTypes {
+	gtypes := make([]GTypes, 0, len(gTypeMappings))
+	for gtype := range gTypeMappings {
+		gtypes = append(gtypes, gtype)
+	}
+	sort.Slice(gtypes, func(i, j int) bool { return gtypes[i] < gtypes[j] })
+	return gtypes
+}
+
 func (gt GTypes) String() string {
 	return string(gt)
 }
@@ -662,6 +689,12 @@ func (gt GTypes) ProtoMessage() proto.Message {
 	return &anypb.Any{}
 }
 
+// StoredAsList reports whether resources of the gtype keep a list of messages,
+// listeners are stored as a list.
+func (gt GTypes) StoredAsList() bool {
+	return gt == Listener
+}
+
 func (gt GTypes) DownstreamFilters(dfm downstreamfilters.DownstreamFilter) []downstreamfilters.MongoFilters {
 	if mapping, exists := gTypeMappings[gt]; exists && mapping.DownstreamFiltersFunc != nil {
 		return mapping.DownstreamFiltersFunc(dfm)
@@ -53,11 +53,13 @@ type General struct {
 	Category        string             `json:"category" bson:"category"`
 	Managed         bool               `json:"managed,omitempty" bson:"managed,omitempty"`
 	Metadata        map[string]any     `json:"metadata" bson:"metadata"`
+	Labels          map[string]string  `json:"labels,omitempty" bson:"labels,omitempty"`
 	Permissions     Permissions        `json:"permissions" bson:"permissions"`
 	ConfigDiscovery []*ConfigDiscovery `json:"config_discovery,omitempty" bson:"config_discovery,omitempty"`
 	TypedConfig     []*TypedConfig     `json:"typed_config,omitempty" bson:"typed_config,omitempty"`
 	CreatedAt       primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty"`
 	UpdatedAt       primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty"`
+	UpdatedBy       string             `json:"updated_by,omitempty" bson:"updated_by,omitempty"`
 }
 
 type Permissions struct {
@@ -1,5 +1,7 @@
 package models
 
+import "fmt"
+
 type Settings struct {
 	Tokens []Token `bson:"tokens"`
 }
@@ -7,4 +9,50 @@ type Settings struct {
 type Token struct {
 	Token string `bson:"token"`
 	Name  string `bson:"name"`
-}
\ No newline at end of file
+}
+
+const (
+	DanglingReferencesReject = "reject"
+	DanglingReferencesWarn   = "warn"
+	DanglingReferencesIgnore = "ignore"
+)
+
+// Severities of lint rules, off disables a rule.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+	LintOff     = "off"
+)
+
+// ProjectSettings are stored on the project document, empty values fall back to the defaults.
+type ProjectSettings struct {
+	DanglingReferences string            `json:"dangling_references,omitempty" bson:"dangling_references,omitempty"`
+	Lint               map[string]string `json:"lint,omitempty" bson:"lint,omitempty"`
+}
+
+func (ps *ProjectSettings) Validate() error {
+	switch ps.DanglingReferences {
+	case "", DanglingReferencesReject, DanglingReferencesWarn, DanglingReferencesIgnore:
+	default:
+		return fmt.Errorf("invalid dangling_references value: %s", ps.DanglingReferences)
+	}
+	for rule, severity := range ps.Lint {
+		switch severity {
+		case LintError, LintWarning, LintOff:
+		default:
+			return fmt.Errorf("invalid lint severity for %s: %s", rule, severity)
+		}
+	}
+	return nil
+}
+
+func (ps *ProjectSettings) WithDefaults() ProjectSettings {
+	settings := ProjectSettings{}
+	if ps != nil {
+		settings = *ps
+	}
+	if settings.DanglingReferences == "" {
+		settings.DanglingReferences = DanglingReferencesReject
+	}
+	return settings
+}
@@ -37,18 +37,28 @@ type User struct {
 }
 
 type Group struct {
-	ID        primitive.ObjectID `bson:"_id"`
-	GroupName *string            `json:"groupname" bson:"groupname" validate:"required,min=2,max=100"`
-	Members   []string           `json:"members" bson:"members"`
-	Project   *string            `json:"project" bson:"project" validate:"required,min=2,max=100"`
-	CreatedAt primitive.DateTime `json:"created_at" bson:"created_at"`
-	UpdatedAt primitive.DateTime `json:"updated_at" bson:"updated_at"`
+	ID          primitive.ObjectID `bson:"_id"`
+	GroupName   *string            `json:"groupname" bson:"groupname" validate:"required,min=2,max=100"`
+	Members     []string           `json:"members" bson:"members"`
+	Project     *string            `json:"project" bson:"project" validate:"required,min=2,max=100"`
+	LabelScopes []LabelScope       `json:"label_scopes,omitempty" bson:"label_scopes,omitempty"`
+	CreatedAt   primitive.DateTime `json:"created_at" bson:"created_at"`
+	UpdatedAt   primitive.DateTime `json:"updated_at" bson:"updated_at"`
+}
+
+// LabelScope grants a group every resource of the collection whose labels match the
+// selector, next to the resources granted one by one. An empty collection stands
+// for every xDS collection.
+type LabelScope struct {
+	Collection string `json:"collection" bson:"collection"`
+	Selector   string `json:"selector" bson:"selector"`
 }
 
 type Project struct {
 	ID          primitive.ObjectID `bson:"_id"`
 	ProjectName *string            `json:"projectname" bson:"projectname" validate:"required,min=2,max=100"`
 	Members     []string           `json:"members" bson:"members"`
+	Settings    *ProjectSettings   `json:"settings,omitempty" bson:"settings,omitempty"`
 	CreatedAt   primitive.DateTime `json:"created_at" bson:"created_at"`
 	UpdatedAt   primitive.DateTime `json:"updated_at" bson:"updated_at"`
 }
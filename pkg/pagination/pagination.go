@@ -0,0 +1,306 @@
+package pagination
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 1000
+)
+
+// ErrInvalidOptions wraps every error caused by the list parameters of the request.
+var ErrInvalidOptions = errors.New("invalid list options")
+
+// Spec describes the sort and field names a list accepts and the document fields
+// behind them. Keep is always part of a fields projection, Projection is used when
+// no fields are requested.
+type Spec struct {
+	Sorts       map[string]string
+	DefaultSort string
+	Fields      map[string]string
+	Keep        []string
+	Projection  bson.M
+}
+
+// Page is the response envelope of a paginated list.
+type Page struct {
+	Items      any    `json:"items"`
+	Total      int64  `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type Result struct {
+	Records    []bson.M
+	Total      int64
+	NextCursor string
+	paged      bool
+}
+
+// Wrap returns the items of an unpaginated list as they are, so lists keep their
+// response until a client asks for pages, and a Page otherwise.
+func (r *Result) Wrap(items any) any {
+	if !r.paged {
+		return items
+	}
+	return Page{Items: items, Total: r.Total, NextCursor: r.NextCursor}
+}
+
+type query struct {
+	field      string
+	descending bool
+	limit      int
+	projection bson.M
+	after      *position
+}
+
+// position is the sort value and id of the last document of a page.
+type position struct {
+	Sort  string `bson:"s"`
+	Value any    `bson:"v"`
+	ID    any    `bson:"id"`
+}
+
+// Find lists the documents of the collection matching the filter in the requested
+// order. A paginated list reads one page after the cursor and counts the filter,
+// the order always ends with _id so pages are stable.
+func Find(ctx context.Context, collection *mongo.Collection, filter bson.M, list models.ListOptions, spec Spec) (*Result, error) {
+	q, err := prepare(list, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(q.sort())
+	if q.projection != nil {
+		opts.SetProjection(q.projection)
+	}
+	if list.Paged() {
+		opts.SetLimit(int64(q.limit + 1))
+	}
+
+	cursor, err := collection.Find(ctx, q.pageFilter(filter), opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not find records: %w", err)
+	}
+
+	var records []bson.M
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("could not decode records: %w", err)
+	}
+
+	return q.result(ctx, collection, filter, records, list.Paged())
+}
+
+// Aggregate is Find for lists which need more stages, they run on the sorted page.
+func Aggregate(ctx context.Context, collection *mongo.Collection, filter bson.M, list models.ListOptions, spec Spec, stages bson.A) (*Result, error) {
+	q, err := prepare(list, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := bson.A{
+		bson.D{{Key: "$match", Value: q.pageFilter(filter)}},
+		bson.D{{Key: "$sort", Value: q.sort()}},
+	}
+	if list.Paged() {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: q.limit + 1}})
+	}
+	pipeline = append(pipeline, stages...)
+	if q.projection != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: q.projection}})
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("could not aggregate records: %w", err)
+	}
+
+	var records []bson.M
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("could not decode records: %w", err)
+	}
+
+	return q.result(ctx, collection, filter, records, list.Paged())
+}
+
+func prepare(list models.ListOptions, spec Spec) (*query, error) {
+	q := &query{limit: list.Limit, projection: spec.Projection}
+	if q.limit <= 0 {
+		q.limit = DefaultLimit
+	}
+	q.limit = min(q.limit, MaxLimit)
+
+	sortName := list.Sort
+	if sortName == "" {
+		sortName = spec.DefaultSort
+	}
+	if name, ok := strings.CutPrefix(sortName, "-"); ok {
+		q.descending = true
+		sortName = name
+	}
+	field, ok := spec.Sorts[sortName]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort %q, use one of %s", ErrInvalidOptions, sortName, strings.Join(names(spec.Sorts), ", "))
+	}
+	q.field = field
+
+	if len(list.Fields) > 0 {
+		q.projection = bson.M{field: 1}
+		for _, keep := range spec.Keep {
+			q.projection[keep] = 1
+		}
+		for _, name := range list.Fields {
+			documentField, ok := spec.Fields[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown field %q, use one of %s", ErrInvalidOptions, name, strings.Join(names(spec.Fields), ", "))
+			}
+			q.projection[documentField] = 1
+		}
+	}
+
+	if list.Cursor != "" {
+		after, err := decodeCursor(list.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if after.Sort != q.sortKey() {
+			return nil, fmt.Errorf("%w: the cursor belongs to another sort order", ErrInvalidOptions)
+		}
+		q.after = after
+	}
+	return q, nil
+}
+
+func (q *query) sortKey() string {
+	if q.descending {
+		return "-" + q.field
+	}
+	return q.field
+}
+
+func (q *query) sort() bson.D {
+	direction := 1
+	if q.descending {
+		direction = -1
+	}
+	if q.field == "_id" {
+		return bson.D{{Key: "_id", Value: direction}}
+	}
+	return bson.D{{Key: q.field, Value: direction}, {Key: "_id", Value: direction}}
+}
+
+// pageFilter narrows the filter to the documents after the cursor. Missing values
+// sort first, so they need their own clauses.
+func (q *query) pageFilter(filter bson.M) bson.M {
+	if q.after == nil {
+		return filter
+	}
+
+	next := "$gt"
+	if q.descending {
+		next = "$lt"
+	}
+
+	var after bson.M
+	switch {
+	case q.field == "_id":
+		after = bson.M{"_id": bson.M{next: q.after.ID}}
+	case q.after.Value == nil && q.descending:
+		after = bson.M{q.field: nil, "_id": bson.M{next: q.after.ID}}
+	case q.after.Value == nil:
+		after = bson.M{"$or": bson.A{
+			bson.M{q.field: nil, "_id": bson.M{next: q.after.ID}},
+			bson.M{q.field: bson.M{"$ne": nil}},
+		}}
+	default:
+		clauses := bson.A{
+			bson.M{q.field: bson.M{next: q.after.Value}},
+			bson.M{q.field: q.after.Value, "_id": bson.M{next: q.after.ID}},
+		}
+		if q.descending {
+			clauses = append(clauses, bson.M{q.field: nil})
+		}
+		after = bson.M{"$or": clauses}
+	}
+
+	return bson.M{"$and": bson.A{filter, after}}
+}
+
+func (q *query) result(ctx context.Context, collection *mongo.Collection, filter bson.M, records []bson.M, paged bool) (*Result, error) {
+	if records == nil {
+		records = []bson.M{}
+	}
+	result := &Result{Records: records, paged: paged}
+	if !paged {
+		return result, nil
+	}
+
+	if len(records) > q.limit {
+		result.Records = records[:q.limit]
+		last := result.Records[q.limit-1]
+		next, err := encodeCursor(position{Sort: q.sortKey(), Value: lookup(last, q.field), ID: last["_id"]})
+		if err != nil {
+			return nil, err
+		}
+		result.NextCursor = next
+	}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("could not count records: %w", err)
+	}
+	result.Total = total
+	return result, nil
+}
+
+func lookup(record bson.M, field string) any {
+	var value any = record
+	for _, key := range strings.Split(field, ".") {
+		document, ok := value.(bson.M)
+		if !ok {
+			return nil
+		}
+		value = document[key]
+	}
+	return value
+}
+
+func encodeCursor(after position) (string, error) {
+	data, err := bson.MarshalExtJSON(after, true, false)
+	if err != nil {
+		return "", fmt.Errorf("could not encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(cursor string) (*position, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidOptions)
+	}
+	var after position
+	if err := bson.UnmarshalExtJSON(data, true, &after); err != nil || after.ID == nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidOptions)
+	}
+	return &after, nil
+}
+
+func names(fields map[string]string) []string {
+	result := make([]string, 0, len(fields))
+	for name := range fields {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
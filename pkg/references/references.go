@@ -0,0 +1,242 @@
+package references
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const (
+	CollectionName = "references"
+	// RebuildCollectionName is where a rebuild writes before it replaces the live collection.
+	RebuildCollectionName = "references_rebuild"
+	stateCollection       = "references_state"
+	stateID               = "index"
+)
+
+// Endpoint is one side of a reference.
+type Endpoint struct {
+	Collection string        `json:"collection" bson:"collection"`
+	Name       string        `json:"name" bson:"name"`
+	GType      models.GTypes `json:"gtype" bson:"gtype"`
+}
+
+// Reference records that From points to To at Path, within one project and version.
+type Reference struct {
+	Project string   `json:"project" bson:"project"`
+	Version string   `json:"version" bson:"version"`
+	From    Endpoint `json:"from" bson:"from"`
+	To      Endpoint `json:"to" bson:"to"`
+	Path    string   `json:"path" bson:"path"`
+}
+
+var indexModels = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "project", Value: 1}, {Key: "version", Value: 1}, {Key: "to.collection", Value: 1}, {Key: "to.name", Value: 1}},
+		Options: options.Index().SetName("project_version_to_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "project", Value: 1}, {Key: "version", Value: 1}, {Key: "from.collection", Value: 1}, {Key: "from.name", Value: 1}},
+		Options: options.Index().SetName("project_version_from_1"),
+	},
+}
+
+// ready is cached once the index has been built, it never goes back to false.
+var ready atomic.Bool
+
+func EnsureIndexes(ctx context.Context, database *mongo.Database) error {
+	_, err := database.Collection(CollectionName).Indexes().CreateMany(ctx, indexModels)
+	return err
+}
+
+// StartRebuild returns an empty rebuild collection with the indexes of the live one,
+// readers keep using the live collection until FinishRebuild.
+func StartRebuild(ctx context.Context, database *mongo.Database) (*mongo.Collection, error) {
+	collection := database.Collection(RebuildCollectionName)
+	if err := collection.Drop(ctx); err != nil {
+		return nil, fmt.Errorf("could not drop %s: %w", RebuildCollectionName, err)
+	}
+	if _, err := collection.Indexes().CreateMany(ctx, indexModels); err != nil {
+		return nil, fmt.Errorf("could not create references indexes: %w", err)
+	}
+	return collection, nil
+}
+
+// FinishRebuild renames the rebuild collection over the live one and marks the index
+// as built.
+func FinishRebuild(ctx context.Context, database *mongo.Database, count int) error {
+	rename := bson.D{
+		{Key: "renameCollection", Value: database.Name() + "." + RebuildCollectionName},
+		{Key: "to", Value: database.Name() + "." + CollectionName},
+		{Key: "dropTarget", Value: true},
+	}
+	if err := database.Client().Database("admin").RunCommand(ctx, rename).Err(); err != nil {
+		return fmt.Errorf("could not replace references: %w", err)
+	}
+	return MarkReady(ctx, database, count)
+}
+
+// Ready reports whether the index was built for existing data. Until then callers
+// fall back to the downstream filters.
+func Ready(ctx context.Context, database *mongo.Database) bool {
+	if ready.Load() {
+		return true
+	}
+
+	err := database.Collection(stateCollection).FindOne(ctx, bson.M{"_id": stateID}).Err()
+	if err != nil {
+		return false
+	}
+
+	ready.Store(true)
+	return true
+}
+
+func MarkReady(ctx context.Context, database *mongo.Database, count int) error {
+	filter := bson.M{"_id": stateID}
+	update := bson.M{"$set": bson.M{"built_at": time.Now(), "references": count}}
+	_, err := database.Collection(stateCollection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("could not mark references index as built: %w", err)
+	}
+
+	ready.Store(true)
+	return nil
+}
+
+// Replace swaps every outgoing reference of the resource with the given ones.
+func Replace(ctx context.Context, database *mongo.Database, project, version string, from Endpoint, refs []Reference) error {
+	if err := RemoveFrom(ctx, database, project, version, from); err != nil {
+		return err
+	}
+
+	if len(refs) == 0 {
+		return nil
+	}
+
+	docs := make([]any, 0, len(refs))
+	for _, ref := range refs {
+		docs = append(docs, ref)
+	}
+
+	if _, err := database.Collection(CollectionName).InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("could not store references of %s/%s: %w", from.Collection, from.Name, err)
+	}
+	return nil
+}
+
+func RemoveFrom(ctx context.Context, database *mongo.Database, project, version string, from Endpoint) error {
+	filter := bson.M{
+		"project":         project,
+		"from.collection": from.Collection,
+		"from.name":       from.Name,
+	}
+	if version != "" {
+		filter["version"] = version
+	}
+
+	if _, err := database.Collection(CollectionName).DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("could not remove references of %s/%s: %w", from.Collection, from.Name, err)
+	}
+	return nil
+}
+
+func RemoveProject(ctx context.Context, database *mongo.Database, project string) error {
+	if project == "" {
+		return errors.New("project is required")
+	}
+
+	_, err := database.Collection(CollectionName).DeleteMany(ctx, bson.M{"project": project})
+	return err
+}
+
+// Referrers returns the distinct resources pointing to the given collection and name.
+func Referrers(ctx context.Context, database *mongo.Database, project, version, collection, name string) ([]Endpoint, error) {
+	filter := bson.M{
+		"project":       project,
+		"version":       version,
+		"to.collection": collection,
+		"to.name":       name,
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$from"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.collection", Value: 1}, {Key: "_id.name", Value: 1}}}},
+	}
+
+	cursor, err := database.Collection(CollectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("could not find referrers of %s/%s: %w", collection, name, err)
+	}
+
+	var results []struct {
+		From Endpoint `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("could not decode referrers of %s/%s: %w", collection, name, err)
+	}
+
+	endpoints := make([]Endpoint, 0, len(results))
+	for _, result := range results {
+		if result.From.Collection == collection && result.From.Name == name {
+			continue
+		}
+		endpoints = append(endpoints, result.From)
+	}
+	return endpoints, nil
+}
+
+// Targets returns the distinct resources the given resource points to.
+func Targets(ctx context.Context, database *mongo.Database, project, version string, from Endpoint) ([]Endpoint, error) {
+	filter := bson.M{
+		"project":         project,
+		"version":         version,
+		"from.collection": from.Collection,
+		"from.name":       from.Name,
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$to"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.collection", Value: 1}, {Key: "_id.name", Value: 1}}}},
+	}
+
+	cursor, err := database.Collection(CollectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("could not find targets of %s/%s: %w", from.Collection, from.Name, err)
+	}
+
+	var results []struct {
+		To Endpoint `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("could not decode targets of %s/%s: %w", from.Collection, from.Name, err)
+	}
+
+	endpoints := make([]Endpoint, 0, len(results))
+	for _, result := range results {
+		if result.To.Collection == from.Collection && result.To.Name == from.Name {
+			continue
+		}
+		endpoints = append(endpoints, result.To)
+	}
+	return endpoints, nil
+}
+
+// GroupByCollection groups referrer names by their collection.
+func GroupByCollection(endpoints []Endpoint) map[string][]string {
+	grouped := make(map[string][]string)
+	for _, endpoint := range endpoints {
+		grouped[endpoint.Collection] = append(grouped[endpoint.Collection], endpoint.Name)
+	}
+	return grouped
+}
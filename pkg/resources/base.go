@@ -22,6 +22,7 @@ import (
 	"github.com/CloudNativeWorks/elchi-backend/pkg/bridge"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/labels"
 	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
 )
 
@@ -202,9 +203,14 @@ func ValidateResourceWithClient(ctx context.Context, resourceGType models.GTypes
 
 func PrepareResource(resource models.ResourceClass, requestDetails models.RequestDetails, logger *logrus.Logger, resourceService *bridge.ResourceServiceClient) error {
 	general := resource.GetGeneral()
+	if err := labels.Validate(general.Labels); err != nil {
+		return err
+	}
+
 	now := time.Now()
 	general.CreatedAt = primitive.NewDateTimeFromTime(now)
 	general.UpdatedAt = primitive.NewDateTimeFromTime(now)
+	general.UpdatedBy = requestDetails.User.UserName
 	resource.SetGeneral(&general)
 	nodeid := fmt.Sprintf("%s::%s", requestDetails.Name, requestDetails.Project)
 
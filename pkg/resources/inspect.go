@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+// FieldReport lists the fields of a resource which the envoy protos do not know,
+// usually removed in that version, and the fields they mark as deprecated.
+type FieldReport struct {
+	Unknown    []string `json:"unknown,omitempty"`
+	Deprecated []string `json:"deprecated,omitempty"`
+}
+
+// InspectFields walks a stored resource against the descriptor of its gtype. The
+// validation unmarshaler discards unknown fields, so this is where they show up.
+func InspectFields(gtype models.GTypes, resource any) FieldReport {
+	var report FieldReport
+	msg := gtype.ProtoMessage()
+	if msg == nil {
+		return report
+	}
+
+	descriptor := msg.ProtoReflect().Descriptor()
+	if items, ok := resource.([]any); ok {
+		for i, item := range items {
+			inspectMessage(descriptor, item, fmt.Sprint(i), &report)
+		}
+	} else {
+		inspectMessage(descriptor, resource, "", &report)
+	}
+
+	sort.Strings(report.Unknown)
+	sort.Strings(report.Deprecated)
+	return report
+}
+
+func inspectMessage(descriptor protoreflect.MessageDescriptor, value any, path string, report *FieldReport) {
+	object, ok := value.(map[string]any)
+	if !ok || strings.HasPrefix(string(descriptor.FullName()), "google.protobuf.") {
+		return
+	}
+
+	fields := descriptor.Fields()
+	for key, child := range object {
+		field := fields.ByName(protoreflect.Name(key))
+		if field == nil {
+			field = fields.ByJSONName(key)
+		}
+
+		fieldPath := joinFieldPath(path, key)
+		if field == nil {
+			report.Unknown = append(report.Unknown, fieldPath)
+			continue
+		}
+		if options, ok := field.Options().(*descriptorpb.FieldOptions); ok && options.GetDeprecated() {
+			report.Deprecated = append(report.Deprecated, fieldPath)
+		}
+
+		switch {
+		case field.IsMap():
+			if field.MapValue().Message() == nil {
+				continue
+			}
+			if entries, ok := child.(map[string]any); ok {
+				for entryKey, entry := range entries {
+					inspectMessage(field.MapValue().Message(), entry, joinFieldPath(fieldPath, entryKey), report)
+				}
+			}
+		case field.Message() == nil:
+			continue
+		case field.IsList():
+			if items, ok := child.([]any); ok {
+				for i, item := range items {
+					inspectMessage(field.Message(), item, joinFieldPath(fieldPath, fmt.Sprint(i)), report)
+				}
+			}
+		default:
+			inspectMessage(field.Message(), child, fieldPath, report)
+		}
+	}
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,103 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/lint"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+)
+
+// CheckLint runs the lint rules on the resource. Findings with error severity reject
+// the save, the others are returned as warnings.
+func CheckLint(ctx context.Context, appCtx *db.AppContext, resource models.ResourceClass, logger *logrus.Logger) ([]lint.Finding, error) {
+	findings, err := Lint(ctx, appCtx, resource, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := lint.Errors(findings)
+	if len(failed) == 0 {
+		return findings, nil
+	}
+
+	details := make([]string, 0, len(failed))
+	for _, finding := range failed {
+		details = append(details, fmt.Sprintf("%s at %s: %s", finding.Rule, finding.Path, finding.Message))
+	}
+	return nil, fmt.Errorf("%w: %s", errstr.ErrLintFailed, strings.Join(details, ", "))
+}
+
+// Lint runs the lint rules on the resource with the severities of its project. Other
+// resources are read from the same project and version.
+func Lint(ctx context.Context, appCtx *db.AppContext, resource models.ResourceClass, logger *logrus.Logger) ([]lint.Finding, error) {
+	general := resource.GetGeneral()
+	settings := common.GetProjectSettings(ctx, appCtx, general.Project)
+
+	doc, err := decodeResource(resource.GetResource(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &lint.Target{
+		General:  general,
+		Resource: doc,
+		Lookup:   &lintLookup{appCtx: appCtx, project: general.Project, version: general.Version, logger: logger},
+	}
+	return lint.Run(ctx, target, settings.Lint)
+}
+
+func decodeResource(resource any, logger *logrus.Logger) (any, error) {
+	jsonStr, err := helper.MarshalJSON(resource, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err := json.Unmarshal([]byte(jsonStr), &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// lintLookup reads the resources of one project and version for the lint rules.
+type lintLookup struct {
+	appCtx  *db.AppContext
+	project string
+	version string
+	logger  *logrus.Logger
+}
+
+func (l *lintLookup) Resource(ctx context.Context, collection, name string) (any, error) {
+	var doc models.DBResource
+	filter := bson.M{"general.name": name, "general.project": l.project, "general.version": l.version}
+	opts := options.FindOne().SetProjection(bson.M{"resource": 1})
+	if err := l.appCtx.Client.Collection(collection).FindOne(ctx, filter, opts).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return decodeResource(doc.GetResource(), l.logger)
+}
+
+// Referrers is empty until the reference index is built.
+func (l *lintLookup) Referrers(ctx context.Context, collection, name string) ([]references.Endpoint, error) {
+	if !references.Ready(ctx, l.appCtx.Client) {
+		return nil, nil
+	}
+	return references.Referrers(ctx, l.appCtx.Client, l.project, l.version, collection, name)
+}
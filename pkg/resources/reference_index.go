@@ -0,0 +1,152 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/references"
+)
+
+// IndexReferences stores the outgoing references of a saved resource in the references collection.
+func IndexReferences(ctx context.Context, appCtx *db.AppContext, resource models.ResourceClass, logger *logrus.Logger) error {
+	general := resource.GetGeneral()
+	from := referenceEndpoint(general)
+
+	refs := buildReferences(general, from, collectReferences(resource, logger))
+	return references.Replace(ctx, appCtx.Client, general.Project, general.Version, from, refs)
+}
+
+// RemoveReferences drops the outgoing references of a deleted resource, an empty version drops all versions.
+func RemoveReferences(ctx context.Context, appCtx *db.AppContext, collection, name, project, version string) error {
+	from := references.Endpoint{Collection: collection, Name: name}
+	return references.RemoveFrom(ctx, appCtx.Client, project, version, from)
+}
+
+// RebuildReferences recreates the references collection from every xds resource and marks it ready.
+// The references are written to a separate collection which then replaces the live one, so
+// readers never see a partial index. References written by resources saved while it runs
+// may be lost.
+func RebuildReferences(ctx context.Context, appCtx *db.AppContext, logger *logrus.Logger) (int, error) {
+	target, err := references.StartRebuild(ctx, appCtx.Client)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, collectionName := range models.XDSCollections() {
+		count, err := rebuildCollection(ctx, appCtx, target, collectionName, logger)
+		if err != nil {
+			return total, err
+		}
+		logger.Infof("indexed %d references of %s", count, collectionName)
+		total += count
+	}
+
+	if err := references.FinishRebuild(ctx, appCtx.Client, total); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+func rebuildCollection(ctx context.Context, appCtx *db.AppContext, target *mongo.Collection, collectionName string, logger *logrus.Logger) (int, error) {
+	cursor, err := appCtx.Client.Collection(collectionName).Find(ctx, bson.M{})
+	if err != nil {
+		return 0, fmt.Errorf("could not read %s: %w", collectionName, err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []any
+	for cursor.Next(ctx) {
+		// decode through a map so nested documents marshal to plain json
+		var raw bson.M
+		if err := cursor.Decode(&raw); err != nil {
+			logger.Errorf("could not decode %s document: %v", collectionName, err)
+			continue
+		}
+
+		resource, err := DecodeDBResource(raw)
+		if err != nil {
+			logger.Errorf("could not convert %s document: %v", collectionName, err)
+			continue
+		}
+
+		general := resource.GetGeneral()
+		if general.Collection == "" {
+			general.Collection = collectionName
+		}
+		from := referenceEndpoint(general)
+		for _, ref := range buildReferences(general, from, collectReferences(resource, logger)) {
+			docs = append(docs, ref)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, err
+	}
+
+	if len(docs) == 0 {
+		return 0, nil
+	}
+
+	if _, err := target.InsertMany(ctx, docs); err != nil {
+		return 0, fmt.Errorf("could not store references of %s: %w", collectionName, err)
+	}
+	return len(docs), nil
+}
+
+// DecodeDBResource converts a raw document through json so the body holds plain json types.
+func DecodeDBResource(raw bson.M) (*models.DBResource, error) {
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var resource models.DBResource
+	if err := json.Unmarshal(jsonData, &resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+func referenceEndpoint(general models.General) references.Endpoint {
+	collection := general.Collection
+	if collection == "" {
+		collection = general.GType.CollectionString()
+	}
+	return references.Endpoint{Collection: collection, Name: general.Name, GType: general.GType}
+}
+
+func buildReferences(general models.General, from references.Endpoint, refs []reference) []references.Reference {
+	result := make([]references.Reference, 0, len(refs))
+	for _, ref := range refs {
+		result = append(result, references.Reference{
+			Project: general.Project,
+			Version: general.Version,
+			From:    from,
+			To:      references.Endpoint{Collection: ref.collection, Name: ref.name, GType: ref.gtype},
+			Path:    ref.path,
+		})
+	}
+	return result
+}
+
+// ReferencedEndpoints returns the distinct resources the given resource points to.
+func ReferencedEndpoints(resource models.ResourceClass, logger *logrus.Logger) []references.Endpoint {
+	seen := make(map[references.Endpoint]struct{})
+	endpoints := []references.Endpoint{}
+	for _, ref := range collectReferences(resource, logger) {
+		endpoint := references.Endpoint{Collection: ref.collection, Name: ref.name, GType: ref.gtype}
+		if _, ok := seen[endpoint]; ok {
+			continue
+		}
+		seen[endpoint] = struct{}{}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
@@ -0,0 +1,231 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/CloudNativeWorks/elchi-backend/controller/crud/common"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/db"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+type DanglingReference struct {
+	Path       string        `json:"path"`
+	Name       string        `json:"name"`
+	GType      models.GTypes `json:"gtype"`
+	Collection string        `json:"collection"`
+}
+
+type reference struct {
+	path       string
+	name       string
+	gtype      models.GTypes
+	collection string
+	// typed references sit in the base64 value of a typed_config at path
+	typed bool
+}
+
+// CheckReferences resolves every outgoing reference of the resource. Depending on the
+// project setting it rejects the save or returns the unresolved references as warnings.
+func CheckReferences(ctx context.Context, appCtx *db.AppContext, resource models.ResourceClass, logger *logrus.Logger) ([]DanglingReference, error) {
+	general := resource.GetGeneral()
+	settings := common.GetProjectSettings(ctx, appCtx, general.Project)
+	if settings.DanglingReferences == models.DanglingReferencesIgnore {
+		return nil, nil
+	}
+
+	dangling, err := FindDanglingReferences(ctx, appCtx, resource, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dangling) == 0 {
+		return nil, nil
+	}
+
+	if settings.DanglingReferences == models.DanglingReferencesWarn {
+		return dangling, nil
+	}
+
+	details := make([]string, 0, len(dangling))
+	for _, ref := range dangling {
+		details = append(details, fmt.Sprintf("%s: %s (%s)", ref.Path, ref.Name, ref.GType.PrettyName()))
+	}
+	return nil, fmt.Errorf("%w: %s", errstr.ErrDanglingReferences, strings.Join(details, ", "))
+}
+
+// FindDanglingReferences returns the references of the resource which point to names
+// that do not exist in the same project and version.
+func FindDanglingReferences(ctx context.Context, appCtx *db.AppContext, resource models.ResourceClass, logger *logrus.Logger) ([]DanglingReference, error) {
+	general := resource.GetGeneral()
+	refs := collectReferences(resource, logger)
+
+	byCollection := make(map[string][]string)
+	for _, ref := range refs {
+		byCollection[ref.collection] = append(byCollection[ref.collection], ref.name)
+	}
+
+	existing := make(map[string]bool)
+	for collection, names := range byCollection {
+		found, err := existingNames(ctx, appCtx, collection, names, general.Project, general.Version)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range found {
+			existing[collection+"|"+name] = true
+		}
+	}
+
+	dangling := []DanglingReference{}
+	for _, ref := range refs {
+		if existing[ref.collection+"|"+ref.name] {
+			continue
+		}
+		dangling = append(dangling, DanglingReference{
+			Path:       ref.path,
+			Name:       ref.name,
+			GType:      ref.gtype,
+			Collection: ref.collection,
+		})
+	}
+
+	sort.Slice(dangling, func(i, j int) bool { return dangling[i].Path < dangling[j].Path })
+	return dangling, nil
+}
+
+func existingNames(ctx context.Context, appCtx *db.AppContext, collection string, names []string, project, version string) ([]string, error) {
+	filter := bson.M{
+		"general.name":    bson.M{"$in": names},
+		"general.project": project,
+		"general.version": version,
+	}
+
+	values, err := appCtx.Client.Collection(collection).Distinct(ctx, "general.name", filter)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve %s references: %w", collection, err)
+	}
+
+	found := make([]string, 0, len(values))
+	for _, value := range values {
+		if name, ok := value.(string); ok {
+			found = append(found, name)
+		}
+	}
+	return found, nil
+}
+
+func collectReferences(resource models.ResourceClass, logger *logrus.Logger) []reference {
+	var refs []reference
+	general := resource.GetGeneral()
+
+	jsonStr, err := helper.MarshalJSON(resource.GetResource(), logger)
+	if err != nil {
+		logger.Errorf("Error marshaling JSON: %v", err)
+		return nil
+	}
+
+	var doc any
+	if err := json.Unmarshal([]byte(jsonStr), &doc); err != nil {
+		logger.Errorf("Error unmarshaling JSON: %v", err)
+		return nil
+	}
+
+	// array resources (virtual hosts) carry their index in the path
+	items := map[string]any{"": doc}
+	if list, ok := doc.([]any); ok {
+		items = make(map[string]any, len(list))
+		for i, item := range list {
+			items[strconv.Itoa(i)+"."] = item
+		}
+	}
+
+	for prefix, item := range items {
+		for path, gtype := range general.GType.UpstreamPaths() {
+			for concretePath, name := range expandPath(item, strings.Split(path, "."), "") {
+				refs = append(refs, reference{path: prefix + concretePath, name: name, gtype: gtype, collection: gtype.CollectionString()})
+			}
+		}
+
+		itemJSON, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		for _, typedConfigPath := range general.GType.TypedConfigPaths() {
+			_, typedConfigs := ProcessTypedConfigs(string(itemJSON), typedConfigPath, logger)
+			for path, tc := range typedConfigs {
+				if tc.Name == "" || tc.Gtype == "" {
+					continue
+				}
+				refs = append(refs, reference{path: prefix + path, name: tc.Name, gtype: tc.Gtype, collection: typedConfigCollection(tc), typed: true})
+			}
+		}
+	}
+
+	for i, cd := range general.ConfigDiscovery {
+		if cd == nil || cd.Name == "" || cd.GType == "" {
+			continue
+		}
+		refs = append(refs, reference{
+			path:       fmt.Sprintf("general.config_discovery.%d", i),
+			name:       cd.Name,
+			gtype:      cd.GType,
+			collection: cd.GType.CollectionString(),
+		})
+	}
+
+	return refs
+}
+
+func typedConfigCollection(tc *models.TypedConfig) string {
+	if tc.Collection != "" {
+		return tc.Collection
+	}
+	return tc.Gtype.CollectionString()
+}
+
+// expandPath resolves a gjson style path with "#" wildcards to concrete paths and their string values.
+func expandPath(node any, segments []string, current string) map[string]string {
+	result := make(map[string]string)
+	if len(segments) == 0 {
+		if value, ok := node.(string); ok && value != "" {
+			result[strings.TrimSuffix(current, ".")] = value
+		}
+		return result
+	}
+
+	segment := segments[0]
+	rest := segments[1:]
+
+	if segment == "#" {
+		list, ok := node.([]any)
+		if !ok {
+			return result
+		}
+		for i, item := range list {
+			for k, v := range expandPath(item, rest, current+strconv.Itoa(i)+".") {
+				result[k] = v
+			}
+		}
+		return result
+	}
+
+	object, ok := node.(map[string]any)
+	if !ok {
+		return result
+	}
+	child, exists := object[segment]
+	if !exists {
+		return result
+	}
+
+	return expandPath(child, rest, current+segment+".")
+}
@@ -0,0 +1,105 @@
+package resources
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/sjson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/helper"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const configDiscoveryPath = "general.config_discovery."
+
+// RewriteReferences points every reference of the resource to collection/name at
+// newName instead, using the same upstream and typed config paths the reference
+// index is built from. It returns the rewritten paths, sorted.
+func RewriteReferences(resource models.ResourceClass, collection, name, newName string, logger *logrus.Logger) ([]string, error) {
+	body, err := helper.MarshalJSON(resource.GetResource(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	general := resource.GetGeneral()
+	rewritten := []string{}
+	for _, ref := range collectReferences(resource, logger) {
+		if ref.collection != collection || ref.name != name {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(ref.path, configDiscoveryPath):
+			index, err := strconv.Atoi(strings.TrimPrefix(ref.path, configDiscoveryPath))
+			if err != nil || index >= len(general.ConfigDiscovery) {
+				return nil, fmt.Errorf("invalid config discovery path %s", ref.path)
+			}
+			general.ConfigDiscovery[index].Name = newName
+		case ref.typed:
+			typedConfig := GetTypedConfigValue(body, ref.path+".value", logger)
+			if typedConfig == nil {
+				return nil, fmt.Errorf("could not read typed config at %s", ref.path)
+			}
+			typedConfig.Name = newName
+			encoded, err := json.Marshal(typedConfig)
+			if err != nil {
+				return nil, err
+			}
+			if body, err = sjson.Set(body, ref.path+".value", base64.StdEncoding.EncodeToString(encoded)); err != nil {
+				return nil, fmt.Errorf("could not rewrite %s: %w", ref.path, err)
+			}
+		default:
+			if body, err = sjson.Set(body, ref.path, newName); err != nil {
+				return nil, fmt.Errorf("could not rewrite %s: %w", ref.path, err)
+			}
+		}
+		rewritten = append(rewritten, ref.path)
+	}
+
+	if len(rewritten) == 0 {
+		return rewritten, nil
+	}
+
+	var updated any
+	if err := json.Unmarshal([]byte(body), &updated); err != nil {
+		return nil, err
+	}
+	resource.SetResource(updated)
+	resource.SetGeneral(&general)
+
+	sort.Strings(rewritten)
+	return rewritten, nil
+}
+
+// selfNameFields hold the name of a resource inside its own envoy body.
+var selfNameFields = []string{"name", "cluster_name"}
+
+// RenameBody replaces the name the resource carries in its own body, the one envoy
+// sees, with newName. Listener bodies are lists and every item is renamed.
+func RenameBody(body any, name, newName string) any {
+	switch value := body.(type) {
+	case map[string]any:
+		for _, field := range selfNameFields {
+			if value[field] == name {
+				value[field] = newName
+			}
+		}
+	case []any:
+		for i := range value {
+			value[i] = RenameBody(value[i], name, newName)
+		}
+	case primitive.A:
+		for i := range value {
+			value[i] = RenameBody(value[i], name, newName)
+		}
+	case primitive.M:
+		RenameBody(map[string]any(value), name, newName)
+	}
+	return body
+}
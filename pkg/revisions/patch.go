@@ -0,0 +1,125 @@
+package revisions
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PatchOperation is a single RFC 6902 operation.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+}
+
+// Document is the part of a revision that is compared by Diff.
+func (r *Revision) Document() map[string]any {
+	return map[string]any{
+		"general":  r.General,
+		"resource": r.Resource,
+	}
+}
+
+// Diff returns the json patch which turns from into to.
+func Diff(from, to any) ([]PatchOperation, error) {
+	fromValue, err := normalize(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toValue, err := normalize(to)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := []PatchOperation{}
+	diffValues(fromValue, toValue, "", &patch)
+	return patch, nil
+}
+
+// normalize round trips through json so both sides use the same plain types.
+func normalize(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func diffValues(from, to any, path string, patch *[]PatchOperation) {
+	fromMap, fromIsMap := from.(map[string]any)
+	toMap, toIsMap := to.(map[string]any)
+	if fromIsMap && toIsMap {
+		diffObjects(fromMap, toMap, path, patch)
+		return
+	}
+
+	fromList, fromIsList := from.([]any)
+	toList, toIsList := to.([]any)
+	if fromIsList && toIsList {
+		diffArrays(fromList, toList, path, patch)
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*patch = append(*patch, PatchOperation{Op: "replace", Path: path, Value: to})
+	}
+}
+
+func diffObjects(from, to map[string]any, path string, patch *[]PatchOperation) {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, exists := from[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := path + "/" + escapePointer(key)
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+
+		switch {
+		case inFrom && !inTo:
+			*patch = append(*patch, PatchOperation{Op: "remove", Path: childPath})
+		case !inFrom && inTo:
+			*patch = append(*patch, PatchOperation{Op: "add", Path: childPath, Value: toValue})
+		default:
+			diffValues(fromValue, toValue, childPath, patch)
+		}
+	}
+}
+
+// diffArrays compares elements by position, trailing elements are added or removed.
+func diffArrays(from, to []any, path string, patch *[]PatchOperation) {
+	common := min(len(from), len(to))
+	for i := 0; i < common; i++ {
+		diffValues(from[i], to[i], path+"/"+strconv.Itoa(i), patch)
+	}
+
+	for i := common; i < len(to); i++ {
+		*patch = append(*patch, PatchOperation{Op: "add", Path: path + "/" + strconv.Itoa(i), Value: to[i]})
+	}
+
+	// remove from the end so earlier indices stay valid
+	for i := len(from) - 1; i >= common; i-- {
+		*patch = append(*patch, PatchOperation{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+	}
+}
+
+func escapePointer(key string) string {
+	key = strings.ReplaceAll(key, "~", "~0")
+	return strings.ReplaceAll(key, "/", "~1")
+}
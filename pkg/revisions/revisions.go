@@ -0,0 +1,151 @@
+package revisions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const CollectionName = "revisions"
+
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Revision is an immutable copy of a resource taken on every write.
+type Revision struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Collection      string             `json:"collection" bson:"collection"`
+	Name            string             `json:"name" bson:"name"`
+	Project         string             `json:"project" bson:"project"`
+	Version         string             `json:"version" bson:"version"`
+	GType           models.GTypes      `json:"gtype" bson:"gtype"`
+	ResourceVersion string             `json:"resource_version" bson:"resource_version"`
+	Operation       string             `json:"operation" bson:"operation"`
+	UserID          string             `json:"user_id" bson:"user_id"`
+	Username        string             `json:"username" bson:"username"`
+	CreatedAt       primitive.DateTime `json:"created_at" bson:"created_at"`
+	General         *models.General    `json:"general,omitempty" bson:"general,omitempty"`
+	Resource        any                `json:"resource,omitempty" bson:"resource,omitempty"`
+}
+
+// ListFilter selects revisions, Access narrows them to those the user can read.
+type ListFilter struct {
+	Project    string
+	Collection string
+	Name       string
+	Version    string
+	Access     bson.M
+}
+
+// documentCollection decodes nested documents as maps so bodies marshal to plain json.
+func documentCollection(database *mongo.Database, name string) *mongo.Collection {
+	opts := options.Collection().SetBSONOptions(&options.BSONOptions{DefaultDocumentM: true})
+	return database.Collection(name, opts)
+}
+
+// Record stores the current state of the resource as a new revision.
+func Record(ctx context.Context, database *mongo.Database, operation string, resource models.ResourceClass, user models.UserDetails) error {
+	general := resource.GetGeneral()
+	if general.Collection == "" {
+		general.Collection = general.GType.CollectionString()
+	}
+
+	resourceVersion, _ := resource.GetVersion().(string)
+	revision := Revision{
+		Collection:      general.Collection,
+		Name:            general.Name,
+		Project:         general.Project,
+		Version:         general.Version,
+		GType:           general.GType,
+		ResourceVersion: resourceVersion,
+		Operation:       operation,
+		UserID:          user.UserID,
+		Username:        user.UserName,
+		CreatedAt:       primitive.NewDateTimeFromTime(time.Now()),
+		General:         &general,
+		Resource:        resource.GetResource(),
+	}
+
+	if _, err := database.Collection(CollectionName).InsertOne(ctx, revision); err != nil {
+		return fmt.Errorf("could not record revision of %s/%s: %w", general.Collection, general.Name, err)
+	}
+	return nil
+}
+
+// LoadResource reads a stored resource, it is used to keep the last state before a delete.
+func LoadResource(ctx context.Context, database *mongo.Database, collection string, filter bson.M) (*models.DBResource, error) {
+	var resource models.DBResource
+	if err := documentCollection(database, collection).FindOne(ctx, filter).Decode(&resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// List returns the revisions matching the filter, newest first, without their bodies.
+func List(ctx context.Context, database *mongo.Database, listFilter ListFilter) ([]Revision, error) {
+	if listFilter.Project == "" {
+		return nil, errors.New("project is required")
+	}
+
+	filter := bson.M{"project": listFilter.Project}
+	if listFilter.Collection != "" {
+		filter["collection"] = listFilter.Collection
+	}
+	if listFilter.Name != "" {
+		filter["name"] = listFilter.Name
+	}
+	if listFilter.Version != "" {
+		filter["version"] = listFilter.Version
+	}
+	maps.Copy(filter, listFilter.Access)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetProjection(bson.M{"general": 0, "resource": 0})
+
+	cursor, err := database.Collection(CollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not list revisions: %w", err)
+	}
+
+	revisions := []Revision{}
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, fmt.Errorf("could not decode revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// Get returns a revision of the project, access narrows it to those the user can read.
+func Get(ctx context.Context, database *mongo.Database, id, project string, access bson.M) (*Revision, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid revision id")
+	}
+
+	filter := bson.M{"_id": objectID, "project": project}
+	maps.Copy(filter, access)
+
+	var revision Revision
+	err = documentCollection(database, CollectionName).FindOne(ctx, filter).Decode(&revision)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
@@ -0,0 +1,172 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	dateTimeType      = reflect.TypeOf(primitive.DateTime(0))
+	objectIDType      = reflect.TypeOf(primitive.ObjectID{})
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// FromType returns the schema of a Go type as encoding/json writes it, for the request
+// and response bodies which are not proto messages. Fields are not marked required,
+// the handlers fill in what is missing.
+func FromType(t reflect.Type) map[string]any {
+	return fromType(t, map[reflect.Type]bool{})
+}
+
+// fromType keeps the structs on the path in seen, a struct referring to itself is
+// described as a plain object the second time.
+func fromType(t reflect.Type, seen map[reflect.Type]bool) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		return fromType(t.Elem(), seen)
+	}
+
+	switch t {
+	case dateTimeType, timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case objectIDType:
+		return map[string]any{"type": "string"}
+	}
+
+	switch {
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return map[string]any{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": fromType(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": fromType(t.Elem(), seen)}
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]any{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		return fromStruct(t, seen)
+	default:
+		return map[string]any{}
+	}
+}
+
+func fromStruct(t reflect.Type, seen map[reflect.Type]bool) map[string]any {
+	properties := map[string]any{}
+	for _, field := range jsonFields(t) {
+		properties[field.name] = fromType(field.typ, seen)
+	}
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+type jsonField struct {
+	name   string
+	typ    reflect.Type
+	depth  int
+	tagged bool
+}
+
+// jsonFields returns the fields encoding/json writes for a struct. The fields of
+// untagged embedded structs are promoted, a name taken by fields at the same depth
+// goes to the only tagged one of them or is dropped.
+func jsonFields(t reflect.Type) []jsonField {
+	var found []jsonField
+	level := []reflect.Type{t}
+	visited := map[reflect.Type]bool{}
+	for depth := 0; len(level) > 0; depth++ {
+		var next []reflect.Type
+		for _, current := range level {
+			if visited[current] {
+				continue
+			}
+			visited[current] = true
+
+			for i := 0; i < current.NumField(); i++ {
+				field := current.Field(i)
+				fieldType := field.Type
+				if field.Anonymous {
+					if fieldType.Kind() == reflect.Pointer {
+						fieldType = fieldType.Elem()
+					}
+					if !field.IsExported() && fieldType.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !field.IsExported() {
+					continue
+				}
+
+				name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+				if name == "-" {
+					continue
+				}
+				if name == "" && field.Anonymous && fieldType.Kind() == reflect.Struct {
+					next = append(next, fieldType)
+					continue
+				}
+				tagged := name != ""
+				if !tagged {
+					name = field.Name
+				}
+				found = append(found, jsonField{name: name, typ: field.Type, depth: depth, tagged: tagged})
+			}
+		}
+		level = next
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].name != found[j].name {
+			return found[i].name < found[j].name
+		}
+		if found[i].depth != found[j].depth {
+			return found[i].depth < found[j].depth
+		}
+		return found[i].tagged && !found[j].tagged
+	})
+
+	var fields []jsonField
+	for i := 0; i < len(found); {
+		j := i + 1
+		for j < len(found) && found[j].name == found[i].name {
+			j++
+		}
+		if dominant, ok := dominantField(found[i:j]); ok {
+			fields = append(fields, dominant)
+		}
+		i = j
+	}
+	return fields
+}
+
+// dominantField picks the field of a name among fields sorted by depth and tag.
+func dominantField(fields []jsonField) (jsonField, bool) {
+	if len(fields) > 1 && fields[0].depth == fields[1].depth && fields[0].tagged == fields[1].tagged {
+		return jsonField{}, false
+	}
+	return fields[0], true
+}
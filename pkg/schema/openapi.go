@@ -0,0 +1,276 @@
+package schema
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const componentsPrefix = "#/components/schemas/"
+
+// Route is a registered endpoint of the REST API, Handler is the name of its handler function.
+type Route struct {
+	Method  string
+	Path    string
+	Handler string
+}
+
+// Operation describes what the handler of a route reads and writes. Query names the
+// query parameters it reads, Request and Response are the types of the bodies, nil
+// when a body has no fixed shape. IfMatch is set for updates checking If-Match.
+type Operation struct {
+	Query    []string
+	Request  reflect.Type
+	Response reflect.Type
+	IfMatch  bool
+}
+
+type parameter struct {
+	description string
+	schema      map[string]any
+}
+
+var stringSchema = map[string]any{"type": "string"}
+
+// queryParameters are the query parameters the handlers read, by name.
+var queryParameters = map[string]parameter{
+	"project":                  {description: "Project of the resources", schema: stringSchema},
+	"version":                  {description: "Envoy version of the resources", schema: stringSchema},
+	"gtype":                    {description: "Type of the resource", schema: stringSchema},
+	"collection":               {description: "Collection of the resource", schema: stringSchema},
+	"name":                     {description: "Name of the resource", schema: stringSchema},
+	"canonical_name":           {description: "Canonical name of an extension", schema: stringSchema},
+	"category":                 {description: "Category of an extension", schema: stringSchema},
+	"resource_id":              {description: "Id of the resource", schema: stringSchema},
+	"selector":                 {description: "Label selector, such as env=prod,tier in (web,api)", schema: stringSchema},
+	"save_or_publish":          {description: "Save the change as a draft or publish it", schema: map[string]any{"type": "string", "enum": []string{models.SaveDraft, models.SavePublish}}},
+	"permanent":                {description: "Delete without moving to the trash, owners only", schema: map[string]any{"type": "string", "enum": []string{"true"}}},
+	"limit":                    {description: "Page size of a list", schema: map[string]any{"type": "integer", "minimum": 0}},
+	"cursor":                   {description: "Cursor of the next page of a list", schema: stringSchema},
+	"sort":                     {description: "Sort field of a list, - for descending", schema: stringSchema},
+	"fields":                   {description: "Comma separated fields to return in a list", schema: stringSchema},
+	"with_service_ips":         {description: "Include the ips of the services of each client", schema: map[string]any{"type": "string", "enum": []string{"true"}}},
+	"for_metrics":              {description: "List every resource for the metrics pages", schema: map[string]any{"type": "string", "enum": []string{"true"}}},
+	"from_client":              {description: "List the services of the client in client_id instead", schema: map[string]any{"type": "string", "enum": []string{"true"}}},
+	"client_id":                {description: "Id of the client", schema: stringSchema},
+	"service_id":               {description: "Id of the service", schema: stringSchema},
+	"metadata_scenario_id":     {description: "Id of the scenario", schema: stringSchema},
+	"metadata_http_filter":     {description: "Http filter the extensions are matched against", schema: stringSchema},
+	"metadata_non_eds_cluster": {description: "Only clusters which are not EDS clusters", schema: map[string]any{"type": "string", "enum": []string{"true"}}},
+	"from":                     {description: "Revision or version to start from", schema: stringSchema},
+	"to":                       {description: "Revision or version to end at", schema: stringSchema},
+	"status":                   {description: "Status of the change sets", schema: stringSchema},
+	"format":                   {description: "Format of the response body", schema: stringSchema},
+	"on_conflict":              {description: "What to do with resources which already exist", schema: map[string]any{"type": "string", "enum": []string{"update", "skip", "fail"}}},
+	"include_secret_keys":      {description: "Export the keys of secrets, owners and admins only", schema: map[string]any{"type": "string", "enum": []string{"true"}}},
+	"isProjectPage":            {description: "List the users of the project page", schema: map[string]any{"type": "string", "enum": []string{"yes"}}},
+}
+
+var resourceType = reflect.TypeOf(models.DBResource{})
+
+var (
+	pathParameter = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+	handlerName   = regexp.MustCompile(`\.([A-Za-z0-9_]+)(-fm|\.func[0-9]+)?$`)
+)
+
+// OpenAPI describes the given routes as an OpenAPI 3.1 document. Operations are looked
+// up by the handler name of a route, routes without one only get their path parameters.
+// Resource bodies refer to the schemas of every gtype generated from the proto descriptors.
+func OpenAPI(routes []Route, operations map[string]Operation, version string) map[string]any {
+	generator := NewGenerator(componentsPrefix)
+	gtypes := models.AllGTypes()
+	resources := make([]any, 0, len(gtypes))
+	gtypeNames := make([]string, 0, len(gtypes))
+	for _, gtype := range gtypes {
+		gtypeNames = append(gtypeNames, gtype.String())
+		if gtype.ProtoMessage() != nil {
+			resources = append(resources, generator.Resource(gtype))
+		}
+	}
+
+	schemas := generator.Definitions
+	schemas["General"] = FromType(reflect.TypeOf(models.General{}))
+	schemas["Resource"] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"general": map[string]any{"$ref": componentsPrefix + "General"},
+			"resource": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"version":  map[string]any{"type": "string"},
+					"resource": map[string]any{"description": "The resource of general.gtype, see GET /api/v3/schema", "anyOf": resources},
+				},
+			},
+		},
+	}
+	schemas["Error"] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"message": map[string]any{"type": "string"},
+			"data":    map[string]any{},
+		},
+	}
+
+	sorted := append([]Route(nil), routes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	paths := map[string]any{}
+	operationIDs := map[string]int{}
+	for _, route := range sorted {
+		path := pathParameter.ReplaceAllString(route.Path, "{$1}")
+		item, ok := paths[path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = operation(route, operations[handler(route)], gtypeNames, operationIDs)
+	}
+
+	return map[string]any{
+		"openapi":           "3.1.0",
+		"jsonSchemaDialect": Draft,
+		"info": map[string]any{
+			"title":   "Elchi API",
+			"version": version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"token":        map[string]any{"type": "apiKey", "in": "header", "name": "token"},
+				"refreshToken": map[string]any{"type": "apiKey", "in": "header", "name": "refresh-token"},
+			},
+		},
+	}
+}
+
+func operation(route Route, described Operation, gtypes []string, operationIDs map[string]int) map[string]any {
+	op := map[string]any{
+		"operationId": operationID(route, operationIDs),
+		"tags":        []string{tag(route.Path)},
+		"responses":   responses(route, described),
+	}
+
+	var parameters []any
+	inPath := map[string]bool{}
+	for _, match := range pathParameter.FindAllStringSubmatch(route.Path, -1) {
+		inPath[match[1]] = true
+		parameters = append(parameters, map[string]any{
+			"name": match[1], "in": "path", "required": true, "schema": stringSchema,
+		})
+	}
+
+	for _, name := range described.Query {
+		query, ok := queryParameters[name]
+		if !ok || inPath[name] {
+			continue
+		}
+		schema := query.schema
+		if name == "gtype" {
+			schema = map[string]any{"type": "string", "enum": gtypes}
+		}
+		parameters = append(parameters, map[string]any{
+			"name": name, "in": "query", "description": query.description, "schema": schema,
+		})
+	}
+	if described.IfMatch {
+		parameters = append(parameters, map[string]any{
+			"name": "If-Match", "in": "header", "description": "Resource version the update is based on", "schema": stringSchema,
+		})
+	}
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+
+	if described.Request != nil {
+		op["requestBody"] = map[string]any{
+			"content": map[string]any{"application/json": map[string]any{"schema": bodySchema(described.Request)}},
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(route.Path, "/api/") || route.Path == "/logout":
+		op["security"] = []any{map[string]any{"token": []string{}}}
+	case route.Path == "/refresh":
+		op["security"] = []any{map[string]any{"refreshToken": []string{}}}
+	}
+	return op
+}
+
+func responses(route Route, described Operation) map[string]any {
+	success := map[string]any{}
+	if described.Response != nil {
+		success = bodySchema(described.Response)
+	}
+	result := map[string]any{
+		"200": map[string]any{
+			"description": "Success",
+			"content":     map[string]any{"application/json": map[string]any{"schema": success}},
+		},
+		"400": map[string]any{
+			"description": "The request failed",
+			"content":     map[string]any{"application/json": map[string]any{"schema": map[string]any{"$ref": componentsPrefix + "Error"}}},
+		},
+	}
+	if described.IfMatch {
+		result["409"] = map[string]any{"description": "The resource changed since the version sent in If-Match"}
+	}
+	if strings.HasPrefix(route.Path, "/api/") {
+		result["401"] = map[string]any{"description": "The token is missing or expired"}
+	}
+	return result
+}
+
+// bodySchema refers to the Resource schema for resources, other types are described
+// as encoding/json writes them.
+func bodySchema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == resourceType:
+		return map[string]any{"$ref": componentsPrefix + "Resource"}
+	case t.Kind() == reflect.Slice && bodySchema(t.Elem())["$ref"] != nil:
+		return map[string]any{"type": "array", "items": bodySchema(t.Elem())}
+	}
+	return FromType(t)
+}
+
+// handler is the name of the handler function of a route.
+func handler(route Route) string {
+	if match := handlerName.FindStringSubmatch(route.Handler); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// operationID names an operation after its handler, routes sharing a handler get a number.
+func operationID(route Route, operationIDs map[string]int) string {
+	id := handler(route)
+	if id == "" {
+		id = strings.ToLower(route.Method) + pathParameter.ReplaceAllString(route.Path, "$1")
+	}
+
+	operationIDs[id]++
+	if count := operationIDs[id]; count > 1 {
+		return id + strconv.Itoa(count)
+	}
+	return id
+}
+
+func tag(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 2 && segments[0] == "api" {
+		return segments[2]
+	}
+	return segments[0]
+}
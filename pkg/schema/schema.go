@@ -0,0 +1,497 @@
+package schema
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+// Draft is the JSON Schema dialect of the generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Patterns of the well known header regexes of the validate rules.
+const (
+	headerNamePattern  = "^:?[0-9a-zA-Z!#$%&'*+\\-.^_|~`]+$"
+	headerValuePattern = "^[^\\u0000-\\u0008\\u000A-\\u001F\\u007F]*$"
+	durationPattern    = "^-?[0-9]+(\\.[0-9]{1,9})?s$"
+)
+
+// Generator converts proto messages to JSON Schema the way protojson reads them, with
+// proto field names. Every message becomes one entry of Definitions, referenced as
+// RefPrefix followed by its full name, so recursive messages stay finite.
+type Generator struct {
+	RefPrefix   string
+	Definitions map[string]any
+}
+
+func NewGenerator(refPrefix string) *Generator {
+	return &Generator{RefPrefix: refPrefix, Definitions: map[string]any{}}
+}
+
+// ForResource returns a standalone schema of the stored resource of a gtype with its
+// definitions.
+func ForResource(gtype models.GTypes) map[string]any {
+	generator := NewGenerator("#/$defs/")
+	document := generator.Resource(gtype)
+	document["$schema"] = Draft
+	document["$defs"] = generator.Definitions
+	return document
+}
+
+// Resource returns the schema of the stored resource of a gtype, a list of its message
+// for gtypes stored as a list.
+func (g *Generator) Resource(gtype models.GTypes) map[string]any {
+	schema := g.Ref(gtype.ProtoMessage().ProtoReflect().Descriptor())
+	if gtype.StoredAsList() {
+		return map[string]any{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+// Ref returns the schema of a message field of the given type. Well known types are
+// inlined, other messages are added to the definitions and referenced.
+func (g *Generator) Ref(md protoreflect.MessageDescriptor) map[string]any {
+	if schema, ok := wellKnown(md); ok {
+		return schema
+	}
+
+	name := string(md.FullName())
+	if _, ok := g.Definitions[name]; !ok {
+		g.Definitions[name] = map[string]any{}
+		g.Definitions[name] = g.message(md)
+	}
+	return map[string]any{"$ref": g.RefPrefix + name}
+}
+
+func (g *Generator) message(md protoreflect.MessageDescriptor) map[string]any {
+	schema := map[string]any{
+		"title":                string(md.Name()),
+		"type":                 "object",
+		"additionalProperties": false,
+	}
+
+	withRules := !rulesDisabled(md)
+	properties := map[string]any{}
+	var required []string
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		property := g.field(fd)
+		if withRules {
+			if rules := fieldRules(fd); rules != nil && applyRules(property, fd, rules) {
+				required = append(required, string(fd.Name()))
+			}
+		}
+		if options, ok := fd.Options().(*descriptorpb.FieldOptions); ok && options.GetDeprecated() {
+			property["deprecated"] = true
+		}
+		properties[string(fd.Name())] = property
+	}
+	schema["properties"] = properties
+
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	if oneofs := oneofConstraints(md, withRules); len(oneofs) > 0 {
+		schema["allOf"] = oneofs
+	}
+	return schema
+}
+
+func (g *Generator) field(fd protoreflect.FieldDescriptor) map[string]any {
+	switch {
+	case fd.IsMap():
+		return map[string]any{"type": "object", "additionalProperties": g.single(fd.MapValue())}
+	case fd.IsList():
+		return map[string]any{"type": "array", "items": g.single(fd)}
+	default:
+		return g.single(fd)
+	}
+}
+
+func (g *Generator) single(fd protoreflect.FieldDescriptor) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "uint32", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]any{"type": []string{"integer", "string"}, "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": []string{"integer", "string"}, "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case protoreflect.EnumKind:
+		return enum(fd.Enum())
+	default:
+		return g.Ref(fd.Message())
+	}
+}
+
+func enum(ed protoreflect.EnumDescriptor) map[string]any {
+	if ed.FullName() == "google.protobuf.NullValue" {
+		return map[string]any{"type": "null"}
+	}
+
+	values := ed.Values()
+	names := make([]any, 0, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		names = append(names, string(values.Get(i).Name()))
+	}
+	return map[string]any{"type": "string", "enum": names}
+}
+
+// wellKnown returns the protojson form of the well known types.
+func wellKnown(md protoreflect.MessageDescriptor) (map[string]any, bool) {
+	switch md.FullName() {
+	case "google.protobuf.Any":
+		return map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"@type": map[string]any{"type": "string"}},
+			"required":   []string{"@type"},
+		}, true
+	case "google.protobuf.Duration":
+		return map[string]any{"type": "string", "pattern": durationPattern}, true
+	case "google.protobuf.Timestamp":
+		return map[string]any{"type": "string", "format": "date-time"}, true
+	case "google.protobuf.FieldMask":
+		return map[string]any{"type": "string"}, true
+	case "google.protobuf.Struct":
+		return map[string]any{"type": "object"}, true
+	case "google.protobuf.ListValue":
+		return map[string]any{"type": "array"}, true
+	case "google.protobuf.Value":
+		return map[string]any{}, true
+	case "google.protobuf.Empty":
+		return map[string]any{"type": "object", "additionalProperties": false}, true
+	case "google.protobuf.BoolValue":
+		return map[string]any{"type": "boolean"}, true
+	case "google.protobuf.StringValue":
+		return map[string]any{"type": "string"}, true
+	case "google.protobuf.BytesValue":
+		return map[string]any{"type": "string", "contentEncoding": "base64"}, true
+	case "google.protobuf.Int32Value":
+		return map[string]any{"type": "integer", "format": "int32"}, true
+	case "google.protobuf.UInt32Value":
+		return map[string]any{"type": "integer", "format": "uint32", "minimum": 0}, true
+	case "google.protobuf.Int64Value":
+		return map[string]any{"type": []string{"integer", "string"}, "format": "int64"}, true
+	case "google.protobuf.UInt64Value":
+		return map[string]any{"type": []string{"integer", "string"}, "format": "uint64"}, true
+	case "google.protobuf.FloatValue":
+		return map[string]any{"type": "number", "format": "float"}, true
+	case "google.protobuf.DoubleValue":
+		return map[string]any{"type": "number", "format": "double"}, true
+	}
+	return nil, false
+}
+
+// oneofConstraints allows at most one field of every oneof, exactly one when the
+// oneof is required.
+func oneofConstraints(md protoreflect.MessageDescriptor, withRules bool) []any {
+	var constraints []any
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() || od.Fields().Len() < 2 && !(withRules && oneofRequired(od)) {
+			continue
+		}
+
+		var choices, present []any
+		for j := 0; j < od.Fields().Len(); j++ {
+			name := string(od.Fields().Get(j).Name())
+			choices = append(choices, map[string]any{"required": []string{name}})
+			present = append(present, map[string]any{"required": []string{name}})
+		}
+		if !(withRules && oneofRequired(od)) {
+			choices = append(choices, map[string]any{"not": map[string]any{"anyOf": present}})
+		}
+		constraints = append(constraints, map[string]any{"oneOf": choices})
+	}
+	return constraints
+}
+
+func oneofRequired(od protoreflect.OneofDescriptor) bool {
+	options := od.Options()
+	if options == nil {
+		return false
+	}
+	required, _ := proto.GetExtension(options, validate.E_Required).(bool)
+	return required
+}
+
+func rulesDisabled(md protoreflect.MessageDescriptor) bool {
+	options := md.Options()
+	if options == nil {
+		return false
+	}
+	disabled, _ := proto.GetExtension(options, validate.E_Disabled).(bool)
+	ignored, _ := proto.GetExtension(options, validate.E_Ignored).(bool)
+	return disabled || ignored
+}
+
+func fieldRules(fd protoreflect.FieldDescriptor) *validate.FieldRules {
+	options := fd.Options()
+	if options == nil {
+		return nil
+	}
+	rules, _ := proto.GetExtension(options, validate.E_Rules).(*validate.FieldRules)
+	return rules
+}
+
+// applyRules adds the validate rules of a field to its schema and reports whether
+// the field is required.
+func applyRules(schema map[string]any, fd protoreflect.FieldDescriptor, rules *validate.FieldRules) bool {
+	required := rules.GetMessage().GetRequired()
+
+	switch typed := rules.GetType().(type) {
+	case nil:
+	case *validate.FieldRules_Repeated:
+		constraints := map[string]any{}
+		copyRule(constraints, "minItems", typed.Repeated.MinItems)
+		copyRule(constraints, "maxItems", typed.Repeated.MaxItems)
+		if typed.Repeated.GetUnique() {
+			constraints["uniqueItems"] = true
+		}
+		if items, ok := schema["items"].(map[string]any); ok && typed.Repeated.GetItems() != nil {
+			applyRules(items, fd, typed.Repeated.GetItems())
+		}
+		merge(schema, constraints, typed.Repeated.GetIgnoreEmpty(), map[string]any{"maxItems": 0})
+	case *validate.FieldRules_Map:
+		constraints := map[string]any{}
+		copyRule(constraints, "minProperties", typed.Map.MinPairs)
+		copyRule(constraints, "maxProperties", typed.Map.MaxPairs)
+		if keys := typed.Map.GetKeys(); keys != nil {
+			names := map[string]any{"type": "string"}
+			applyRules(names, fd.MapKey(), keys)
+			delete(names, "type")
+			constraints["propertyNames"] = names
+		}
+		if values, ok := schema["additionalProperties"].(map[string]any); ok && typed.Map.GetValues() != nil {
+			applyRules(values, fd.MapValue(), typed.Map.GetValues())
+		}
+		merge(schema, constraints, typed.Map.GetIgnoreEmpty(), map[string]any{"maxProperties": 0})
+	case *validate.FieldRules_Enum:
+		applyEnumRules(schema, fd.Enum(), typed.Enum)
+	case *validate.FieldRules_Any:
+		required = required || typed.Any.GetRequired()
+		if in := typed.Any.GetIn(); len(in) > 0 {
+			if properties, ok := schema["properties"].(map[string]any); ok {
+				properties["@type"] = map[string]any{"type": "string", "enum": in}
+			}
+		}
+	case *validate.FieldRules_Duration:
+		required = required || typed.Duration.GetRequired()
+	case *validate.FieldRules_Timestamp:
+		required = required || typed.Timestamp.GetRequired()
+	case *validate.FieldRules_Bytes:
+		// byte lengths do not map onto the base64 form
+	default:
+		rejectsZero := applyScalarRules(schema, rules)
+		// without presence an unset field reads as its zero value, so rules rejecting
+		// the zero value make the field required
+		required = required || rejectsZero && !fd.HasPresence() && !fd.IsList() && !fd.IsMap()
+	}
+	return required
+}
+
+// applyScalarRules maps the number, bool and string rules, which share their field
+// names, and reports whether they reject the zero value.
+func applyScalarRules(schema map[string]any, rules *validate.FieldRules) bool {
+	reflected := rules.ProtoReflect()
+	set := reflected.WhichOneof(reflected.Descriptor().Oneofs().ByName("type"))
+	if set == nil || set.Message() == nil {
+		return false
+	}
+
+	typed := reflected.Get(set).Message()
+	constraints := map[string]any{}
+	var patterns []any
+	ignoreEmpty, rejectsZero := false, false
+	typed.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch fd.Name() {
+		case "const":
+			constraints["const"] = value.Interface()
+			rejectsZero = rejectsZero || !isZero(value)
+		case "lt":
+			constraints["exclusiveMaximum"] = value.Interface()
+			rejectsZero = rejectsZero || sign(value) <= 0
+		case "lte":
+			constraints["maximum"] = value.Interface()
+			rejectsZero = rejectsZero || sign(value) < 0
+		case "gt":
+			constraints["exclusiveMinimum"] = value.Interface()
+			rejectsZero = rejectsZero || sign(value) >= 0
+		case "gte":
+			constraints["minimum"] = value.Interface()
+			rejectsZero = rejectsZero || sign(value) > 0
+		case "in":
+			constraints["enum"] = listValues(value.List())
+		case "not_in":
+			constraints["not"] = map[string]any{"enum": listValues(value.List())}
+		case "len":
+			constraints["minLength"] = value.Interface()
+			constraints["maxLength"] = value.Interface()
+			rejectsZero = rejectsZero || value.Uint() > 0
+		case "min_len":
+			constraints["minLength"] = value.Interface()
+			rejectsZero = rejectsZero || value.Uint() > 0
+		case "min_bytes", "len_bytes":
+			rejectsZero = rejectsZero || value.Uint() > 0
+		case "max_len":
+			constraints["maxLength"] = value.Interface()
+		case "pattern":
+			patterns = append(patterns, value.String())
+		case "prefix":
+			patterns = append(patterns, "^"+regexp.QuoteMeta(value.String()))
+		case "suffix":
+			patterns = append(patterns, regexp.QuoteMeta(value.String())+"$")
+		case "contains":
+			patterns = append(patterns, regexp.QuoteMeta(value.String()))
+		case "email", "hostname", "ipv4", "ipv6", "uri", "uuid":
+			constraints["format"] = string(fd.Name())
+		case "uri_ref":
+			constraints["format"] = "uri-reference"
+		case "ip":
+			constraints["anyOf"] = []any{map[string]any{"format": "ipv4"}, map[string]any{"format": "ipv6"}}
+		case "well_known_regex":
+			switch validate.KnownRegex(value.Enum()) {
+			case validate.KnownRegex_HTTP_HEADER_NAME:
+				patterns = append(patterns, headerNamePattern)
+			case validate.KnownRegex_HTTP_HEADER_VALUE:
+				patterns = append(patterns, headerValuePattern)
+			}
+		case "ignore_empty":
+			ignoreEmpty = value.Bool()
+		}
+		return true
+	})
+
+	if len(patterns) == 1 {
+		constraints["pattern"] = patterns[0]
+	}
+	if len(patterns) > 1 {
+		allOf := make([]any, 0, len(patterns))
+		for _, pattern := range patterns {
+			allOf = append(allOf, map[string]any{"pattern": pattern})
+		}
+		constraints["allOf"] = allOf
+	}
+
+	empty := map[string]any{"const": 0}
+	if _, ok := rules.GetType().(*validate.FieldRules_String_); ok {
+		empty = map[string]any{"const": ""}
+	}
+	merge(schema, constraints, ignoreEmpty, empty)
+	return rejectsZero && !ignoreEmpty
+}
+
+func applyEnumRules(schema map[string]any, ed protoreflect.EnumDescriptor, rules *validate.EnumRules) {
+	if ed == nil {
+		return
+	}
+	name := func(number int32) any {
+		if value := ed.Values().ByNumber(protoreflect.EnumNumber(number)); value != nil {
+			return string(value.Name())
+		}
+		return number
+	}
+
+	if rules.Const != nil {
+		schema["const"] = name(rules.GetConst())
+	}
+	if in := rules.GetIn(); len(in) > 0 {
+		names := make([]any, 0, len(in))
+		for _, number := range in {
+			names = append(names, name(number))
+		}
+		schema["enum"] = names
+	}
+	if notIn := rules.GetNotIn(); len(notIn) > 0 {
+		names := make([]any, 0, len(notIn))
+		for _, number := range notIn {
+			names = append(names, name(number))
+		}
+		schema["not"] = map[string]any{"enum": names}
+	}
+}
+
+// merge adds the constraints to the schema. With ignoreEmpty they only apply when the
+// value is not the empty value.
+func merge(schema, constraints map[string]any, ignoreEmpty bool, empty map[string]any) {
+	if len(constraints) == 0 {
+		return
+	}
+	if ignoreEmpty {
+		schema["anyOf"] = []any{empty, constraints}
+		return
+	}
+	for key, value := range constraints {
+		schema[key] = value
+	}
+}
+
+func copyRule(constraints map[string]any, key string, value *uint64) {
+	if value != nil {
+		constraints[key] = *value
+	}
+}
+
+func isZero(value protoreflect.Value) bool {
+	switch typed := value.Interface().(type) {
+	case string:
+		return typed == ""
+	case bool:
+		return !typed
+	}
+	return sign(value) == 0
+}
+
+// sign returns the sign of a number value, 0 for other values.
+func sign(value protoreflect.Value) int {
+	var number float64
+	switch typed := value.Interface().(type) {
+	case int32:
+		number = float64(typed)
+	case int64:
+		number = float64(typed)
+	case uint32:
+		number = float64(typed)
+	case uint64:
+		number = float64(typed)
+	case float32:
+		number = float64(typed)
+	case float64:
+		number = typed
+	}
+	switch {
+	case number > 0:
+		return 1
+	case number < 0:
+		return -1
+	}
+	return 0
+}
+
+func listValues(list protoreflect.List) []any {
+	values := make([]any, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		values = append(values, list.Get(i).Interface())
+	}
+	return values
+}
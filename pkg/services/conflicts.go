@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/errstr"
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+// Socket is an address a listener binds. Rewritten is set for the main address of a
+// listener, whose ip is replaced with the downstream address of the client.
+type Socket struct {
+	Address   string
+	Port      uint32
+	Protocol  string
+	Rewritten bool
+}
+
+// Binding is an ip:port a listener of a service binds on a client.
+type Binding struct {
+	Listener    string `json:"listener"`
+	Project     string `json:"project"`
+	ProjectName string `json:"project_name,omitempty"`
+	ClientID    string `json:"client_id"`
+	Address     string `json:"address"`
+	Port        uint32 `json:"port"`
+	Protocol    string `json:"protocol"`
+}
+
+// Conflict is a binding of the checked listener which another binding already takes.
+type Conflict struct {
+	Binding  Binding `json:"binding"`
+	Existing Binding `json:"existing"`
+}
+
+func (c Conflict) String() string {
+	project := c.Existing.ProjectName
+	if project == "" {
+		project = c.Existing.Project
+	}
+	return fmt.Sprintf("%s:%d/%s of listener %s on client %s is already bound by listener %s of project %s",
+		c.Binding.Address, c.Binding.Port, c.Binding.Protocol, c.Binding.Listener, c.Binding.ClientID, c.Existing.Listener, project)
+}
+
+// ListenerSockets returns the socket addresses of the listeners of a listener resource.
+func ListenerSockets(resource any) []Socket {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil
+	}
+	var listeners []struct {
+		Address             listenerAddress `json:"address"`
+		AdditionalAddresses []struct {
+			Address listenerAddress `json:"address"`
+		} `json:"additional_addresses"`
+	}
+	if err := json.Unmarshal(data, &listeners); err != nil {
+		return nil
+	}
+
+	var sockets []Socket
+	for _, listener := range listeners {
+		if socket, ok := listener.Address.socket(true); ok {
+			sockets = append(sockets, socket)
+		}
+		for _, additional := range listener.AdditionalAddresses {
+			if socket, ok := additional.Address.socket(false); ok {
+				sockets = append(sockets, socket)
+			}
+		}
+	}
+	return sockets
+}
+
+type listenerAddress struct {
+	SocketAddress *struct {
+		Address   string `json:"address"`
+		PortValue uint32 `json:"port_value"`
+		Protocol  string `json:"protocol"`
+	} `json:"socket_address"`
+}
+
+func (a listenerAddress) socket(rewritten bool) (Socket, bool) {
+	if a.SocketAddress == nil || a.SocketAddress.PortValue == 0 {
+		return Socket{}, false
+	}
+	protocol := a.SocketAddress.Protocol
+	if protocol == "" {
+		protocol = "TCP"
+	}
+	return Socket{Address: a.SocketAddress.Address, Port: a.SocketAddress.PortValue, Protocol: protocol, Rewritten: rewritten}, true
+}
+
+// Bindings returns the ip:port pairs the sockets of a listener take on the clients.
+func Bindings(listener, project string, sockets []Socket, clients []models.ServiceClients) []Binding {
+	var bindings []Binding
+	for _, client := range clients {
+		for _, socket := range sockets {
+			address := socket.Address
+			if socket.Rewritten && client.DownstreamAddress != "" {
+				address = client.DownstreamAddress
+			}
+			bindings = append(bindings, Binding{
+				Listener: listener,
+				Project:  project,
+				ClientID: client.ClientID,
+				Address:  address,
+				Port:     socket.Port,
+				Protocol: socket.Protocol,
+			})
+		}
+	}
+	return bindings
+}
+
+// ListenerConflicts checks the bindings of a listener resource of a version on the
+// given clients against each other and against the listeners of every service, in any
+// project, which runs on one of the clients.
+func ListenerConflicts(ctx context.Context, db *mongo.Database, listener, project, version string, resource any, clients []models.ServiceClients) ([]Conflict, error) {
+	bindings := Bindings(listener, project, ListenerSockets(resource), clients)
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+
+	var conflicts []Conflict
+	for i := range bindings {
+		for j := 0; j < i; j++ {
+			if overlaps(bindings[i], bindings[j]) {
+				conflicts = append(conflicts, Conflict{Binding: bindings[i], Existing: bindings[j]})
+			}
+		}
+	}
+
+	existing, err := clientBindings(ctx, db, listener, project, clients)
+	if err != nil {
+		return nil, err
+	}
+	for _, binding := range bindings {
+		for _, other := range existing {
+			if overlaps(binding, other) {
+				conflicts = append(conflicts, Conflict{Binding: binding, Existing: other})
+			}
+		}
+	}
+
+	if err := setProjectNames(ctx, db, conflicts); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// clientBindings returns the bindings of the listeners of other services on the clients.
+// A service runs the listener of the version of its newest bootstrap. The service of
+// the checked listener is skipped in every version, another version of the listener is
+// the same service switching versions.
+func clientBindings(ctx context.Context, db *mongo.Database, listener, project string, clients []models.ServiceClients) ([]Binding, error) {
+	clientIDs := make([]string, 0, len(clients))
+	for _, client := range clients {
+		clientIDs = append(clientIDs, client.ClientID)
+	}
+
+	cursor, err := db.Collection("services").Find(ctx, bson.M{"clients.client_id": bson.M{"$in": clientIDs}})
+	if err != nil {
+		return nil, err
+	}
+	var services []struct {
+		Name    string                  `bson:"name"`
+		Project string                  `bson:"project"`
+		Clients []models.ServiceClients `bson:"clients"`
+	}
+	if err := cursor.All(ctx, &services); err != nil {
+		return nil, err
+	}
+
+	var bindings []Binding
+	for _, service := range services {
+		if service.Name == listener && service.Project == project {
+			continue
+		}
+		serviceVersion, err := bootstrapVersion(ctx, db, service.Name, service.Project)
+		if err != nil {
+			return nil, err
+		}
+		if serviceVersion == "" {
+			continue
+		}
+
+		var shared []models.ServiceClients
+		for _, client := range service.Clients {
+			for _, clientID := range clientIDs {
+				if client.ClientID == clientID {
+					shared = append(shared, client)
+					break
+				}
+			}
+		}
+
+		opts := options.Find().SetProjection(bson.M{"resource.resource": 1})
+		filter := bson.M{"general.name": service.Name, "general.project": service.Project, "general.version": serviceVersion}
+		listenerCursor, err := db.Collection("listeners").Find(ctx, filter, opts)
+		if err != nil {
+			return nil, err
+		}
+		var listeners []models.DBResource
+		if err := listenerCursor.All(ctx, &listeners); err != nil {
+			return nil, err
+		}
+		for _, found := range listeners {
+			bindings = append(bindings, Bindings(service.Name, service.Project, ListenerSockets(found.Resource.Resource), shared)...)
+		}
+	}
+	return bindings, nil
+}
+
+// bootstrapVersion returns the version of the newest bootstrap of a service, a service
+// has a bootstrap per version of its listener. Empty when the service has none.
+func bootstrapVersion(ctx context.Context, db *mongo.Database, service, project string) (string, error) {
+	var bootstrap models.DBResource
+	opts := options.FindOne().
+		SetProjection(bson.M{"general.version": 1}).
+		SetSort(bson.D{{Key: "general.created_at", Value: -1}, {Key: "general.version", Value: -1}})
+	err := db.Collection("bootstrap").FindOne(ctx, bson.M{"general.name": service, "general.project": project}, opts).Decode(&bootstrap)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return bootstrap.General.Version, nil
+}
+
+// overlaps reports whether two bindings take the same port on a client, a wildcard
+// address overlaps with every address.
+func overlaps(a, b Binding) bool {
+	if a.ClientID != b.ClientID || a.Port != b.Port || !strings.EqualFold(a.Protocol, b.Protocol) {
+		return false
+	}
+	return a.Address == b.Address || isWildcard(a.Address) || isWildcard(b.Address)
+}
+
+func isWildcard(address string) bool {
+	return address == "" || address == "0.0.0.0" || address == "::"
+}
+
+func setProjectNames(ctx context.Context, db *mongo.Database, conflicts []Conflict) error {
+	ids := map[string]primitive.ObjectID{}
+	for _, conflict := range conflicts {
+		if id, err := primitive.ObjectIDFromHex(conflict.Existing.Project); err == nil {
+			ids[conflict.Existing.Project] = id
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectIDs = append(objectIDs, id)
+	}
+	opts := options.Find().SetProjection(bson.M{"projectname": 1})
+	cursor, err := db.Collection("projects").Find(ctx, bson.M{"_id": bson.M{"$in": objectIDs}}, opts)
+	if err != nil {
+		return err
+	}
+	var projects []struct {
+		ID   primitive.ObjectID `bson:"_id"`
+		Name string             `bson:"projectname"`
+	}
+	if err := cursor.All(ctx, &projects); err != nil {
+		return err
+	}
+
+	names := make(map[string]string, len(projects))
+	for _, project := range projects {
+		names[project.ID.Hex()] = project.Name
+	}
+	for i := range conflicts {
+		conflicts[i].Existing.ProjectName = names[conflicts[i].Existing.Project]
+	}
+	return nil
+}
+
+// CheckListenerConflicts fails with every conflict of the listener on the clients.
+func CheckListenerConflicts(ctx context.Context, db *mongo.Database, listener, project, version string, resource any, clients []models.ServiceClients) error {
+	conflicts, err := ListenerConflicts(ctx, db, listener, project, version, resource, clients)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	details := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		details = append(details, conflict.String())
+	}
+	return fmt.Errorf("%w: %s", errstr.ErrListenerConflict, strings.Join(details, "; "))
+}
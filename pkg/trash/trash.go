@@ -0,0 +1,206 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/CloudNativeWorks/elchi-backend/pkg/models"
+)
+
+const CollectionName = "trash"
+
+// DefaultRetention is how long deleted resources are kept when no retention is configured.
+const DefaultRetention = 30 * 24 * time.Hour
+
+var ErrEntryNotFound = errors.New("trash entry not found")
+
+// Entry is a deleted resource with the records deleted together with it, such as
+// the bootstrap, service and admin port of a listener. General is a copy of the
+// general section of the document, so the permission filter of the live collections
+// applies to the trash as well.
+type Entry struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Collection string             `json:"collection" bson:"collection"`
+	Name       string             `json:"name" bson:"name"`
+	Project    string             `json:"project" bson:"project"`
+	Version    string             `json:"version" bson:"version"`
+	GType      models.GTypes      `json:"gtype" bson:"gtype"`
+	DeletedBy  string             `json:"deleted_by" bson:"deleted_by"`
+	DeletedAt  primitive.DateTime `json:"deleted_at" bson:"deleted_at"`
+	General    models.General     `json:"-" bson:"general"`
+	Document   bson.M             `json:"-" bson:"document"`
+	Related    []Related          `json:"related,omitempty" bson:"related,omitempty"`
+}
+
+// Related is a record deleted together with the resource, it is restored with it.
+type Related struct {
+	Collection string `json:"collection" bson:"collection"`
+	Name       string `json:"name" bson:"name"`
+	Document   bson.M `json:"-" bson:"document"`
+}
+
+type ListFilter struct {
+	Project    string
+	Collection string
+	Name       string
+	Version    string
+}
+
+var indexModels = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "project", Value: 1}, {Key: "collection", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().SetName("project_collection_name_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+		Options: options.Index().SetName("deleted_at_1"),
+	},
+}
+
+func EnsureIndexes(ctx context.Context, database *mongo.Database) error {
+	_, err := database.Collection(CollectionName).Indexes().CreateMany(ctx, indexModels)
+	return err
+}
+
+// documentCollection decodes nested documents as maps, so restored documents are
+// written back with the types they were read with.
+func documentCollection(database *mongo.Database, name string) *mongo.Collection {
+	opts := options.Collection().SetBSONOptions(&options.BSONOptions{DefaultDocumentM: true})
+	return database.Collection(name, opts)
+}
+
+// LoadDocument reads the document matched by filter as it is stored.
+func LoadDocument(ctx context.Context, database *mongo.Database, collection string, filter bson.M) (bson.M, error) {
+	var document bson.M
+	if err := documentCollection(database, collection).FindOne(ctx, filter).Decode(&document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// Put stores a deleted document of collection with the records deleted together with it.
+func Put(ctx context.Context, database *mongo.Database, collection string, document bson.M, related []Related, user models.UserDetails) (*Entry, error) {
+	data, err := bson.Marshal(document["general"])
+	if err != nil {
+		return nil, fmt.Errorf("could not read general of deleted document: %w", err)
+	}
+	var general models.General
+	if err := bson.Unmarshal(data, &general); err != nil {
+		return nil, fmt.Errorf("could not read general of deleted document: %w", err)
+	}
+
+	entry := &Entry{
+		Collection: collection,
+		Name:       general.Name,
+		Project:    general.Project,
+		Version:    general.Version,
+		GType:      general.GType,
+		DeletedBy:  user.UserName,
+		DeletedAt:  primitive.NewDateTimeFromTime(time.Now()),
+		General:    general,
+		Document:   document,
+		Related:    related,
+	}
+
+	result, err := database.Collection(CollectionName).InsertOne(ctx, entry)
+	if err != nil {
+		return nil, fmt.Errorf("could not move %s/%s to the trash: %w", collection, general.Name, err)
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		entry.ID = id
+	}
+	return entry, nil
+}
+
+// List returns the entries matching the filter, latest deletion first, without
+// their documents. extra narrows the list further, it is used for permissions.
+func List(ctx context.Context, database *mongo.Database, listFilter ListFilter, extra bson.M) ([]Entry, error) {
+	if listFilter.Project == "" {
+		return nil, errors.New("project is required")
+	}
+
+	filter := bson.M{}
+	for key, value := range extra {
+		filter[key] = value
+	}
+	filter["project"] = listFilter.Project
+	if listFilter.Collection != "" {
+		filter["collection"] = listFilter.Collection
+	}
+	if listFilter.Name != "" {
+		filter["name"] = listFilter.Name
+	}
+	if listFilter.Version != "" {
+		filter["version"] = listFilter.Version
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "deleted_at", Value: -1}}).
+		SetProjection(bson.M{"general": 0, "document": 0, "related.document": 0})
+
+	cursor, err := database.Collection(CollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not list trash: %w", err)
+	}
+
+	entries := []Entry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("could not decode trash: %w", err)
+	}
+	return entries, nil
+}
+
+// Get returns the entry with its documents. extra narrows the lookup, it is used
+// for permissions.
+func Get(ctx context.Context, database *mongo.Database, id, project string, extra bson.M) (*Entry, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid trash entry id")
+	}
+
+	filter := bson.M{}
+	for key, value := range extra {
+		filter[key] = value
+	}
+	filter["_id"] = objectID
+	filter["project"] = project
+
+	var entry Entry
+	if err := documentCollection(database, CollectionName).FindOne(ctx, filter).Decode(&entry); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func Delete(ctx context.Context, database *mongo.Database, id primitive.ObjectID) error {
+	if _, err := database.Collection(CollectionName).DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("could not delete trash entry: %w", err)
+	}
+	return nil
+}
+
+// Purge deletes the entries deleted before the given time and returns their number.
+func Purge(ctx context.Context, database *mongo.Database, before time.Time) (int64, error) {
+	result, err := database.Collection(CollectionName).DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lt": primitive.NewDateTimeFromTime(before)}})
+	if err != nil {
+		return 0, fmt.Errorf("could not purge trash: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+func RemoveProject(ctx context.Context, database *mongo.Database, project string) error {
+	if _, err := database.Collection(CollectionName).DeleteMany(ctx, bson.M{"project": project}); err != nil {
+		return fmt.Errorf("could not remove trash of project %s: %w", project, err)
+	}
+	return nil
+}